@@ -0,0 +1,84 @@
+package utils
+
+import "fmt"
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// OverflowError is the panic raised by the paranoid build when a calculation
+// no longer fits in its type.
+type OverflowError struct {
+	Op   string
+	A, B any
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("integer overflow: %v %s %v (%T)", e.A, e.Op, e.B, e.A)
+}
+
+func AddChecked[T Integer](a, b T) (T, bool) {
+	sum := a + b
+	if b < 0 {
+		return sum, sum < a
+	}
+	return sum, sum >= a
+}
+
+func SubChecked[T Integer](a, b T) (T, bool) {
+	diff := a - b
+	if b < 0 {
+		return diff, diff > a
+	}
+	return diff, diff <= a
+}
+
+func MulChecked[T Integer](a, b T) (T, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	product := a * b
+
+	// For signed types, -1 * MinInt wraps back round to MinInt, which the
+	// division check does not catch (as MinInt / -1 overflows as well).
+	var zero T
+	if a == zero-1 && b < 0 && -b == b {
+		return product, false
+	}
+
+	return product, product/a == b
+}
+
+// Digits is the number of decimal digits in x, ignoring any sign.
+func Digits[T Integer](x T) int {
+	digits := 1
+	for x /= 10; x != 0; x /= 10 {
+		digits++
+	}
+	return digits
+}
+
+// ConcatChecked writes the digits of b after the digits of a, so that
+// 12 || 345 = 12345.
+func ConcatChecked[T Integer](a, b T) (T, bool) {
+	// 0 || b is just b, even when the shift past b would not fit.
+	if a == 0 {
+		return b, true
+	}
+
+	shift := T(1)
+	for i := Digits(b); i > 0; i-- {
+		var ok bool
+		if shift, ok = MulChecked(shift, 10); !ok {
+			return 0, false
+		}
+	}
+
+	shifted, ok := MulChecked(a, shift)
+	if !ok {
+		return 0, false
+	}
+	return AddChecked(shifted, b)
+}
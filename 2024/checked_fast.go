@@ -0,0 +1,27 @@
+//go:build !paranoid
+
+package utils
+
+// Paranoid is set by building with `-tags paranoid`, which makes Add, Mul and
+// Concat check every operation for overflow.
+const Paranoid = false
+
+func Add[T Integer](a, b T) T {
+	return a + b
+}
+
+func Sub[T Integer](a, b T) T {
+	return a - b
+}
+
+func Mul[T Integer](a, b T) T {
+	return a * b
+}
+
+func Concat[T Integer](a, b T) T {
+	shift := T(10)
+	for rest := b / 10; rest != 0; rest /= 10 {
+		shift *= 10
+	}
+	return a*shift + b
+}
@@ -0,0 +1,39 @@
+//go:build paranoid
+
+package utils
+
+// Paranoid is set by building with `-tags paranoid`, which makes Add, Mul and
+// Concat check every operation for overflow.
+const Paranoid = true
+
+func Add[T Integer](a, b T) T {
+	sum, ok := AddChecked(a, b)
+	if !ok {
+		panic(&OverflowError{Op: "+", A: a, B: b})
+	}
+	return sum
+}
+
+func Sub[T Integer](a, b T) T {
+	diff, ok := SubChecked(a, b)
+	if !ok {
+		panic(&OverflowError{Op: "-", A: a, B: b})
+	}
+	return diff
+}
+
+func Mul[T Integer](a, b T) T {
+	product, ok := MulChecked(a, b)
+	if !ok {
+		panic(&OverflowError{Op: "*", A: a, B: b})
+	}
+	return product
+}
+
+func Concat[T Integer](a, b T) T {
+	result, ok := ConcatChecked(a, b)
+	if !ok {
+		panic(&OverflowError{Op: "||", A: a, B: b})
+	}
+	return result
+}
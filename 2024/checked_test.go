@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+type checkedCase[T Integer] struct {
+	name string
+	a, b T
+	want T
+	ok   bool
+}
+
+func testChecked[T Integer](t *testing.T, op string, f func(a, b T) (T, bool), tests []checkedCase[T]) {
+	t.Helper()
+	for _, test := range tests {
+		got, ok := f(test.a, test.b)
+		if ok != test.ok || (ok && got != test.want) {
+			t.Errorf("%s: %v %s %v = %v, %t, want %v, %t", test.name, test.a, op, test.b, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestAddChecked(t *testing.T) {
+	testChecked(t, "+", AddChecked[int64], []checkedCase[int64]{
+		{"small", 2, 3, 5, true},
+		{"negative", -2, -3, -5, true},
+		{"to the max", math.MaxInt64 - 1, 1, math.MaxInt64, true},
+		{"past the max", math.MaxInt64, 1, 0, false},
+		{"to the min", math.MinInt64 + 1, -1, math.MinInt64, true},
+		{"past the min", math.MinInt64, -1, 0, false},
+		{"opposite signs", math.MaxInt64, math.MinInt64, -1, true},
+	})
+	testChecked(t, "+", AddChecked[uint64], []checkedCase[uint64]{
+		{"to the max", math.MaxUint64 - 1, 1, math.MaxUint64, true},
+		{"wrapping", math.MaxUint64, 1, 0, false},
+		{"zero", math.MaxUint64, 0, math.MaxUint64, true},
+	})
+	testChecked(t, "+", AddChecked[uint8], []checkedCase[uint8]{
+		{"wrapping", 200, 100, 0, false},
+	})
+}
+
+func TestSubChecked(t *testing.T) {
+	testChecked(t, "-", SubChecked[int64], []checkedCase[int64]{
+		{"small", 2, 3, -1, true},
+		{"to the min", math.MinInt64 + 1, 1, math.MinInt64, true},
+		{"past the min", math.MinInt64, 1, 0, false},
+		{"negating the min", 0, math.MinInt64, 0, false},
+		{"to the max", -1, math.MinInt64, math.MaxInt64, true},
+	})
+	testChecked(t, "-", SubChecked[uint64], []checkedCase[uint64]{
+		{"to zero", 1, 1, 0, true},
+		{"wrapping", 0, 1, 0, false},
+		{"zero", 0, 0, 0, true},
+	})
+}
+
+func TestMulChecked(t *testing.T) {
+	testChecked(t, "*", MulChecked[int64], []checkedCase[int64]{
+		{"small", -4, 5, -20, true},
+		{"zero", math.MinInt64, 0, 0, true},
+		{"min by -1", math.MinInt64, -1, 0, false},
+		{"-1 by min", -1, math.MinInt64, 0, false},
+		{"min by 1", math.MinInt64, 1, math.MinInt64, true},
+		{"max by -1", math.MaxInt64, -1, -math.MaxInt64, true},
+		{"to the min", math.MinInt64 / 2, 2, math.MinInt64, true},
+		{"past the max", math.MaxInt64/2 + 1, 2, 0, false},
+	})
+	testChecked(t, "*", MulChecked[int8], []checkedCase[int8]{
+		{"min by -1", math.MinInt8, -1, 0, false},
+		{"-1 by min", -1, math.MinInt8, 0, false},
+	})
+	testChecked(t, "*", MulChecked[uint64], []checkedCase[uint64]{
+		{"to the max", math.MaxUint64 / 5, 5, math.MaxUint64, true},
+		{"wrapping", 1 << 32, 1 << 32, 0, false},
+		{"MaxUint64 by 1", math.MaxUint64, 1, math.MaxUint64, true},
+		{"MaxUint64 by 2", math.MaxUint64, 2, 0, false},
+	})
+}
+
+func TestConcatChecked(t *testing.T) {
+	testChecked(t, "||", ConcatChecked[uint64], []checkedCase[uint64]{
+		{"small", 12, 345, 12345, true},
+		{"zero after", 12, 0, 120, true},
+		{"zero before", 0, 12, 12, true},
+		{"1e18 after 1", 1, 1e18, 11e18, true},
+		{"to the max", 1, 8446744073709551615, math.MaxUint64, true},
+		{"past the max", 1, 8446744073709551616, 0, false},
+		{"twenty digits after 1", 1, 1e19, 0, false},
+		{"twenty digits after 0", 0, 1e19, 1e19, true},
+		{"twenty digits before 0", 1e19, 0, 0, false},
+		{"nineteen nines after 1", 1, 1e19 - 1, 0, false},
+	})
+	testChecked(t, "||", ConcatChecked[int64], []checkedCase[int64]{
+		{"to the max", 9, 223372036854775807, math.MaxInt64, true},
+		{"past the max", 9, 223372036854775808, 0, false},
+	})
+}
+
+func TestDigits(t *testing.T) {
+	tests := []struct {
+		x    int64
+		want int
+	}{
+		{0, 1},
+		{9, 1},
+		{10, 2},
+		{-10, 2},
+		{math.MaxInt64, 19},
+		{math.MinInt64, 19},
+	}
+
+	for _, test := range tests {
+		if got := Digits(test.x); got != test.want {
+			t.Errorf("Digits(%d) = %d, want %d", test.x, got, test.want)
+		}
+	}
+	if got := Digits(uint64(math.MaxUint64)); got != 20 {
+		t.Errorf("Digits(MaxUint64) = %d, want 20", got)
+	}
+}
+
+// TestUnchecked runs Add, Sub, Mul and Concat, which only panic in the
+// paranoid build.
+func TestUnchecked(t *testing.T) {
+	if got := Concat(uint64(1), uint64(8446744073709551615)); got != math.MaxUint64 {
+		t.Errorf("Concat to the max gave %d", got)
+	}
+
+	overflows := map[string]func(){
+		"Add":    func() { Add(uint64(math.MaxUint64), 1) },
+		"Sub":    func() { Sub(uint64(0), 1) },
+		"Mul":    func() { Mul(int64(math.MinInt64), -1) },
+		"Concat": func() { Concat(uint64(1), uint64(1e19)) },
+	}
+	for name, f := range overflows {
+		func() {
+			defer func() {
+				r := recover()
+				if !Paranoid {
+					if r != nil {
+						t.Errorf("%s panicked outside the paranoid build: %v", name, r)
+					}
+					return
+				}
+
+				var overflow *OverflowError
+				if err, ok := r.(error); !ok || !errors.As(err, &overflow) {
+					t.Errorf("%s gave %v, want an OverflowError", name, r)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,122 @@
+package utils
+
+// Cycle describes the eventual periodic behaviour of a state machine that is
+// advanced one step at a time. Every finite state machine ends up in a loop;
+// Start is the first step that is part of that loop, and Length is how many
+// steps it takes to come back around.
+//
+// The step function given to any of the Detect functions must not modify the
+// state it is given, as earlier states may be revisited.
+type Cycle[T any] struct {
+	Start  int
+	Length int
+
+	initial T
+	step    func(T) T
+	history []T
+}
+
+// DetectCycle finds the cycle by remembering every state seen in a map.
+// This is the fastest option, and keeps all the states so that StateAt is
+// a lookup, but uses memory proportional to Start + Length.
+func DetectCycle[T comparable](initial T, step func(T) T) Cycle[T] {
+	return DetectCycleFunc(initial, step, func(state T) T { return state })
+}
+
+// DetectCycleFunc is DetectCycle for states which are not comparable (such as
+// slices or grids). key must return the same value for two states if and only
+// if the states are equal.
+func DetectCycleFunc[T any, K comparable](initial T, step func(T) T, key func(T) K) Cycle[T] {
+	seen := make(map[K]int)
+	history := make([]T, 0)
+	state := initial
+
+	for i := 0; ; i++ {
+		k := key(state)
+
+		if first, ok := seen[k]; ok {
+			return Cycle[T]{
+				Start:   first,
+				Length:  i - first,
+				initial: initial,
+				step:    step,
+				history: history,
+			}
+		}
+
+		seen[k] = i
+		history = append(history, state)
+		state = step(state)
+	}
+}
+
+// DetectCycleBrent finds the cycle using Brent's algorithm, which only keeps
+// two states in memory at a time. The trade-off is that the step function is
+// called roughly three times as often, and StateAt has to re-run the machine.
+func DetectCycleBrent[T comparable](initial T, step func(T) T) Cycle[T] {
+	return DetectCycleBrentFunc(initial, step, func(a, b T) bool { return a == b })
+}
+
+// DetectCycleBrentFunc is DetectCycleBrent for states which are not comparable.
+func DetectCycleBrentFunc[T any](initial T, step func(T) T, equal func(a, b T) bool) Cycle[T] {
+	// Find the length of the cycle: the tortoise waits at power-of-two steps
+	// for the hare to come back round to it.
+	power, length := 1, 1
+	tortoise := initial
+	hare := step(initial)
+
+	for !equal(tortoise, hare) {
+		if power == length {
+			tortoise = hare
+			power <<= 1
+			length = 0
+		}
+		hare = step(hare)
+		length++
+	}
+
+	// Then find the start: with the hare a full cycle ahead of the tortoise,
+	// they first meet at the beginning of the cycle.
+	tortoise, hare = initial, initial
+	for i := 0; i < length; i++ {
+		hare = step(hare)
+	}
+
+	start := 0
+	for !equal(tortoise, hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		start++
+	}
+
+	return Cycle[T]{
+		Start:   start,
+		Length:  length,
+		initial: initial,
+		step:    step,
+	}
+}
+
+// Equivalent maps step n onto the earliest step that has the same state.
+func (c Cycle[T]) Equivalent(n int) int {
+	if n < c.Start {
+		return n
+	}
+	return c.Start + (n-c.Start)%c.Length
+}
+
+// StateAt returns the state after n steps, for any n >= 0.
+func (c Cycle[T]) StateAt(n int) T {
+	n = c.Equivalent(n)
+
+	if c.history != nil {
+		return c.history[n]
+	}
+
+	state := c.initial
+	for ; n > 0; n-- {
+		state = c.step(state)
+	}
+
+	return state
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// table is a state machine over 0..len-1 where state s steps to table[s].
+type table []int
+
+func (t table) step(s int) int {
+	return t[s]
+}
+
+func TestDetectCycle(t *testing.T) {
+	tests := []struct {
+		name   string
+		table  table
+		start  int
+		length int
+	}{
+		// 0 -> 1 -> 2 -> 3 -> 4 -> 2
+		{"tail then loop", table{1, 2, 3, 4, 2}, 2, 3},
+		// 0 -> 1 -> 2 -> 0
+		{"loop from step 0", table{1, 2, 0}, 0, 3},
+		// 0 -> 1 -> 2 -> 2
+		{"fixed point", table{1, 2, 2}, 2, 1},
+		{"fixed point at the start", table{0}, 0, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			detectors := map[string]Cycle[int]{
+				"map":       DetectCycle(0, test.table.step),
+				"func":      DetectCycleFunc(0, test.table.step, func(s int) int { return s }),
+				"brent":     DetectCycleBrent(0, test.table.step),
+				"brentFunc": DetectCycleBrentFunc(0, test.table.step, func(a, b int) bool { return a == b }),
+			}
+
+			for name, cycle := range detectors {
+				if cycle.Start != test.start || cycle.Length != test.length {
+					t.Errorf("%s: got start %d length %d, want %d and %d", name, cycle.Start, cycle.Length, test.start, test.length)
+				}
+
+				// StateAt far beyond the cycle agrees with just running it.
+				state := 0
+				for n := range 50 {
+					if got := cycle.StateAt(n); got != state {
+						t.Errorf("%s: StateAt(%d) = %d, want %d", name, n, got, state)
+					}
+					state = test.table.step(state)
+				}
+			}
+		})
+	}
+}
+
+func TestStateAtExtrapolates(t *testing.T) {
+	cycle := DetectCycle(0, table{1, 2, 3, 4, 2}.step)
+
+	// Steps 2, 3, 4 repeat, so step 1e12 is 2 + (1e12-2)%3 = 2 + 2.
+	if got := cycle.StateAt(1_000_000_000_000); got != 4 {
+		t.Errorf("got %d, want 4", got)
+	}
+	if got := cycle.Equivalent(1_000_000_000_000); got != 4 {
+		t.Errorf("got equivalent step %d, want 4", got)
+	}
+	if got := cycle.Equivalent(1); got != 1 {
+		t.Errorf("steps before the cycle should map to themselves, got %d", got)
+	}
+}
+
+// TestDetectorsAgree runs both detectors on random functional graphs, where
+// every state steps to a random one.
+func TestDetectorsAgree(t *testing.T) {
+	rng := rand.New(rand.NewPCG(26, 0))
+
+	for range 500 {
+		size := 1 + rng.IntN(200)
+		random := make(table, size)
+		for i := range random {
+			random[i] = rng.IntN(size)
+		}
+		initial := rng.IntN(size)
+
+		byMap := DetectCycle(initial, random.step)
+		byBrent := DetectCycleBrent(initial, random.step)
+
+		if byMap.Start != byBrent.Start || byMap.Length != byBrent.Length {
+			t.Fatalf("%v from %d: map gives start %d length %d, Brent start %d length %d",
+				random, initial, byMap.Start, byMap.Length, byBrent.Start, byBrent.Length)
+		}
+		for _, n := range []int{0, byMap.Start, byMap.Start + byMap.Length, 12345} {
+			if byMap.StateAt(n) != byBrent.StateAt(n) {
+				t.Fatalf("%v from %d: StateAt(%d) differs", random, initial, n)
+			}
+		}
+	}
+}
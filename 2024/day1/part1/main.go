@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"os"
 	"sort"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
@@ -35,7 +34,7 @@ func main() {
 
 func loadData() ([]int, []int) {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-1.txt")
+	dataFile, err := utils.OpenInput(1)
 
 	if err != nil {
 		log.Fatal(err)
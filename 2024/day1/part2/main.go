@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"os"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
 )
@@ -13,7 +12,7 @@ const initialLines = 1000
 
 func main() {
 	defer utils.TimeTrack(time.Now(), "main")
-	dataFile, err := os.Open("2024/input-1.txt")
+	dataFile, err := utils.OpenInput(1)
 
 	if err != nil {
 		log.Fatal(err)
@@ -34,9 +33,9 @@ func main() {
 	utils.TimeTrack(sortStart, "process")
 }
 
-func processInputFile(file *os.File) ([]int, map[int]int) {
+func processInputFile(file io.Reader) ([]int, map[int]int) {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-1.txt")
+	dataFile, err := utils.OpenInput(1)
 
 	if err != nil {
 		log.Fatal(err)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"image"
 	"io"
-	"os"
 	utils "tea-cats.co.uk/aoc/2024"
 	"time"
 )
@@ -27,7 +26,7 @@ func (grid *Grid) At(point image.Point) PointHeight {
 
 func LoadData() (Grid, [10][]image.Point) {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-10.txt")
+	dataFile, err := utils.OpenInput(10)
 
 	if err != nil {
 		panic(err)
@@ -3,7 +3,6 @@ package day11
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 	"tea-cats.co.uk/aoc/2024"
@@ -18,7 +17,7 @@ type BlinkResult []StoneCount
 
 type ResultCache map[StoneValue]BlinkResult
 
-var PowersOfTen = [...]StoneValue{1, 10, 100, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16}
+var PowersOfTen = [...]StoneValue{1, 10, 100, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19}
 var CacheHits = uint64(0)
 var CacheMisses = uint64(0)
 
@@ -61,13 +60,11 @@ func (cache *ResultCache) GetCachedCount(stone StoneValue, blinks BlinkCount) St
 		if Debug {
 			fmt.Printf("x -> 2024*x\n")
 		}
-		target := stone * 2024
+		target := utils.Mul(stone, 2024)
 		stones = cache.GetCachedCount(target, blinks-1)
 		copy(cached[1:], (*cache)[target][:blinks-1])
 	} else {
-		digits := 0
-		for ; stone >= PowersOfTen[digits]; digits++ {
-		}
+		digits := utils.Digits(stone)
 
 		if Debug {
 			fmt.Printf("%d has digits: %d\n", stone, digits)
@@ -77,7 +74,7 @@ func (cache *ResultCache) GetCachedCount(stone StoneValue, blinks BlinkCount) St
 			if Debug {
 				fmt.Printf("xx -> 2024*xx\n")
 			}
-			target := stone * 2024
+			target := utils.Mul(stone, 2024)
 			stones = cache.GetCachedCount(target, blinks-1)
 			copy(cached[1:], (*cache)[target][:blinks-1])
 		} else {
@@ -152,7 +149,7 @@ func CacheStats(knownSequence ResultCache) {
 
 func LoadData() []StoneValue {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-11.txt")
+	dataFile, err := utils.OpenInput(11)
 
 	if err != nil {
 		panic(err)
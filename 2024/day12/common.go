@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"image"
 	"io"
-	"os"
 	utils "tea-cats.co.uk/aoc/2024"
 	"time"
 )
@@ -95,7 +94,7 @@ func LocateRegions(data *utils.Grid[GridPoint]) []Region {
 
 func LoadData() utils.Grid[GridPoint] {
 	defer utils.TimeTrack(time.Now(), "LoadData")
-	dataFile, err := os.Open("2024/input-12.txt")
+	dataFile, err := utils.OpenInput(12)
 
 	if err != nil {
 		panic(err)
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"image"
 	"io"
-	"os"
+	"math/big"
 	utils "tea-cats.co.uk/aoc/2024"
 	"time"
 )
@@ -16,9 +16,67 @@ type Request struct {
 	Target  image.Point
 }
 
+// Presses finds how many times each button needs to be pressed to reach the
+// target, if there is a whole number solution.
+func (r Request) Presses() (a int, b int, ok bool) {
+	// Initial Matrix:
+	// / r.ButtonA.X   r.ButtonB.X \ / A \  _ / r.Target.X \
+	// \ r.ButtonA.Y   r.ButtonB.Y / \ B /  - \ r.Target.Y /
+
+	// So, we need to invert that matrix
+	determinate, ok1 := cross(r.ButtonA, r.ButtonB)
+	expectedA, ok2 := cross(r.Target, r.ButtonB)
+	expectedB, ok3 := cross(r.ButtonA, r.Target)
+
+	if !ok1 || !ok2 || !ok3 {
+		return r.pressesBig()
+	}
+
+	if determinate == 0 || expectedA%determinate != 0 || expectedB%determinate != 0 {
+		return 0, 0, false
+	}
+
+	return expectedA / determinate, expectedB / determinate, true
+}
+
+// cross is the 2D cross product, p.X*q.Y - q.X*p.Y, and whether it fitted in an int.
+func cross(p, q image.Point) (int, bool) {
+	left, ok1 := utils.MulChecked(p.X, q.Y)
+	right, ok2 := utils.MulChecked(q.X, p.Y)
+	result, ok3 := utils.SubChecked(left, right)
+
+	return result, ok1 && ok2 && ok3
+}
+
+// pressesBig is Presses for when the intermediate values overflow an int.
+func (r Request) pressesBig() (a int, b int, ok bool) {
+	crossBig := func(p, q image.Point) *big.Int {
+		left := new(big.Int).Mul(big.NewInt(int64(p.X)), big.NewInt(int64(q.Y)))
+		right := new(big.Int).Mul(big.NewInt(int64(q.X)), big.NewInt(int64(p.Y)))
+		return left.Sub(left, right)
+	}
+
+	determinate := crossBig(r.ButtonA, r.ButtonB)
+	if determinate.Sign() == 0 {
+		return 0, 0, false
+	}
+
+	expectedA, remainderA := new(big.Int).QuoRem(crossBig(r.Target, r.ButtonB), determinate, new(big.Int))
+	expectedB, remainderB := new(big.Int).QuoRem(crossBig(r.ButtonA, r.Target), determinate, new(big.Int))
+
+	if remainderA.Sign() != 0 || remainderB.Sign() != 0 {
+		return 0, 0, false
+	}
+	if !expectedA.IsInt64() || !expectedB.IsInt64() {
+		panic(fmt.Sprintf("button presses for %v do not fit in an int: a=%v b=%v", r, expectedA, expectedB))
+	}
+
+	return int(expectedA.Int64()), int(expectedB.Int64()), true
+}
+
 func LoadData() []Request {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-13.txt")
+	dataFile, err := utils.OpenInput(13)
 
 	if err != nil {
 		panic(err)
@@ -18,27 +18,14 @@ func main() {
 	defer utils.TimeTrack(time.Now(), "process")
 
 	for _, test := range requests {
-		// Initial Matrix:
-		// / test.ButtonA.X   test.ButtonB.X \ / A \  _ / test.Target.X \
-		// \ test.ButtonA.Y   test.ButtonB.Y / \ B /  - \ test.Target.Y /
-
-		// So, we need to invert that matrix
-		determinate := test.ButtonA.X*test.ButtonB.Y - test.ButtonB.X*test.ButtonA.Y
-		expectedA := test.ButtonB.Y*test.Target.X - test.ButtonB.X*test.Target.Y
-		expectedB := test.ButtonA.X*test.Target.Y - test.ButtonA.Y*test.Target.X
+		a, b, ok := test.Presses()
 
 		if debug {
-			fmt.Println("determinate:", determinate, "expected:", expectedA, "expected:", expectedB)
+			fmt.Println("a:", a, "b:", b, "solvable:", ok)
 		}
 
-		if expectedA%determinate == 0 && expectedB%determinate == 0 {
-			expectedA = expectedA / determinate
-			expectedB = expectedB / determinate
-			s := 3*expectedA + expectedB
-			if debug {
-				fmt.Println("a:", expectedA, "b:", expectedB, "score:", s)
-			}
-			score += s
+		if ok {
+			score = utils.Add(score, utils.Add(utils.Mul(3, a), b))
 		}
 	}
 
@@ -0,0 +1,63 @@
+package day14
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	utils "tea-cats.co.uk/aoc/2024"
+	"time"
+)
+
+const debug = false
+
+// Grid is the size of the bathroom the robots patrol in the real puzzle.
+var Grid = image.Rectangle{Min: image.Point{X: 0, Y: 0}, Max: image.Point{X: 101, Y: 103}}
+
+type robot struct {
+	initial  image.Point
+	movement image.Point
+}
+
+func (r *robot) finalPosition(grid image.Rectangle, seconds int) image.Point {
+	return r.initial.Add(r.movement.Mul(seconds)).Mod(grid)
+}
+
+// Input is the robots, and the grid they wrap around. LoadData always uses
+// Grid, as the size is not part of the input.
+type Input struct {
+	Robots []robot
+	Grid   image.Rectangle
+}
+
+func LoadData(raw []byte) (Input, error) {
+	defer utils.TimeTrack(time.Now(), "loadData")
+
+	input := Input{Grid: Grid}
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		r := robot{}
+
+		count, err := fmt.Sscanf(scanner.Text(), "p=%d,%d v=%d,%d", &r.initial.X, &r.initial.Y, &r.movement.X, &r.movement.Y)
+		if err != nil {
+			return Input{}, fmt.Errorf("invalid robot %q: %w", scanner.Text(), err)
+		}
+		if count != 4 {
+			return Input{}, fmt.Errorf("invalid robot %q", scanner.Text())
+		}
+
+		input.Robots = append(input.Robots, r)
+	}
+
+	return input, scanner.Err()
+}
+
+// center returns the middle of the grid, which belongs to no quadrant.
+func (input Input) center() image.Point {
+	return input.Grid.Max.Sub(input.Grid.Min).Sub(image.Point{1, 1}).Div(2)
+}
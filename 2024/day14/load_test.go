@@ -0,0 +1,57 @@
+package day14
+
+import (
+	"context"
+	"image"
+	"io"
+	"log"
+	"os"
+	"testing"
+)
+
+// FuzzLoadData checks that the parts can work on whatever robots the loader
+// accepts, however fast they move, and that the second part 2 picks really
+// has every robot on its own tile.
+func FuzzLoadData(f *testing.F) {
+	log.SetOutput(io.Discard)
+
+	raw, err := os.ReadFile("testdata/example-1.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(raw)
+	f.Add([]byte("p=0,0 v=0,0\np=0,0 v=0,0\n"))
+	f.Add([]byte("p=-5,200 v=-9223372036854775808,9223372036854775807\n"))
+	f.Add([]byte("p=1,2 v=3\n"))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		input, err := LoadData(raw)
+		if err != nil {
+			return
+		}
+
+		ctx := context.Background()
+		if _, err := Part1(ctx, input); err != nil {
+			t.Fatal(err)
+		}
+		if len(input.Robots) > 10 {
+			return
+		}
+
+		second, err := Part2(ctx, input)
+		if err != nil {
+			return
+		}
+		occupied := make(map[image.Point]struct{}, len(input.Robots))
+		for _, robot := range input.Robots {
+			final := robot.finalPosition(input.Grid, second.(int))
+			if !final.In(input.Grid) {
+				t.Fatalf("%v is at %v after %d seconds, off the grid", robot, final, second)
+			}
+			if _, ok := occupied[final]; ok {
+				t.Fatalf("two robots are at %v after %d seconds", final, second)
+			}
+			occupied[final] = struct{}{}
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package day14
+
+import (
+	"context"
+	"fmt"
+	"image"
+)
+
+// Part1 is the safety factor: the product of how many robots are in each
+// quadrant after 100 seconds.
+func Part1(_ context.Context, input Input) (any, error) {
+	grid := input.Grid
+	seconds := 100
+	center := input.center()
+
+	counts := make(map[image.Point]int)
+	quadrants := [4]int{0, 0, 0, 0}
+
+	for i, robot := range input.Robots {
+		final := robot.finalPosition(grid, seconds)
+		if debug {
+			fmt.Printf("Robot %d ends at %v\n", i, final)
+			counts[final]++
+		}
+
+		if final.X == center.X || final.Y == center.Y {
+			continue
+		}
+
+		quadrant := 0
+
+		if final.X < center.X {
+			quadrant = 1
+		}
+		if final.Y < center.Y {
+			quadrant += 2
+		}
+		quadrants[quadrant]++
+	}
+
+	if debug {
+		point := image.Point{}
+		for point.Y = grid.Min.Y; point.Y < grid.Max.Y; point.Y++ {
+			for point.X = grid.Min.X; point.X < grid.Max.X; point.X++ {
+				count, ok := counts[point]
+				if point.X == center.X {
+					fmt.Printf(" ")
+				} else if point.Y == center.Y {
+					fmt.Printf(" ")
+				} else if !ok || count == 0 {
+					fmt.Printf(".")
+				} else if count > 9 {
+					fmt.Printf("+")
+				} else {
+					fmt.Printf("%d", count)
+				}
+			}
+			fmt.Printf("\n")
+		}
+
+		fmt.Printf("Grid=%v, Center=%v, Quadrants=%v\n", grid, center, quadrants)
+	}
+
+	return quadrants[0] * quadrants[1] * quadrants[2] * quadrants[3], nil
+}
@@ -0,0 +1,45 @@
+package day14
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"tea-cats.co.uk/aoc/runner"
+)
+
+// Part2 finds the first second at which no two robots share a tile, which is
+// when they form the picture of the tree. The robots are back where they
+// started after width * height seconds, so there is no point looking further.
+func Part2(ctx context.Context, input Input) (any, error) {
+	size := input.Grid.Size()
+
+nextSecond:
+	for second := 0; second < size.X*size.Y; second++ {
+		if second%100 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			runner.Report(ctx, "checked %d of %d seconds", second, size.X*size.Y)
+		}
+
+		counts := make(map[image.Point]int)
+
+		for i, robot := range input.Robots {
+			final := robot.finalPosition(input.Grid, second)
+
+			old, ok := counts[final]
+			if ok {
+				if debug {
+					fmt.Printf("Time %d: Robot %d (%v) is standing on %d (%v)!\n", second, i, robot, old, input.Robots[old])
+				}
+				continue nextSecond
+			}
+			counts[final] = i
+		}
+
+		return second, nil
+	}
+
+	return nil, errors.New("robots always share a tile")
+}
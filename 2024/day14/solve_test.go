@@ -0,0 +1,70 @@
+package day14
+
+import (
+	"context"
+	"image"
+	"tea-cats.co.uk/aoc/2024/generate"
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/runner/runnertest"
+	"testing"
+)
+
+// exampleGrid is the size of the bathroom in the examples, which is much
+// smaller than the real one.
+var exampleGrid = image.Rectangle{Max: image.Point{X: 11, Y: 7}}
+
+// solveExample solves a part on the example's smaller grid.
+func solveExample(part func(context.Context, Input) (any, error)) runner.SolveFunc {
+	return runner.Parsed(LoadData, func(ctx context.Context, input Input) (any, error) {
+		input.Grid = exampleGrid
+		return part(ctx, input)
+	})
+}
+
+func TestExamples(t *testing.T) {
+	runnertest.Examples(t, []runnertest.Example{
+		{Name: "part1", File: "testdata/example-1.txt", Solve: solveExample(Part1), Want: "12"},
+	})
+}
+
+// TestPictureGenerated checks that generated swarms, however small, have
+// exactly one second in the period when no two robots share a tile, and that
+// Part2 finds it.
+func TestPictureGenerated(t *testing.T) {
+	for _, robots := range []int{1, 50, 500} {
+		for seed := uint64(1); seed <= 3; seed++ {
+			input, err := LoadData(generate.Day14(generate.NewRand(seed), robots))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			unstacked := make([]int, 0, 1)
+			occupied := make(map[image.Point]struct{}, len(input.Robots))
+
+		nextSecond:
+			for second := range input.Grid.Dx() * input.Grid.Dy() {
+				clear(occupied)
+				for _, robot := range input.Robots {
+					final := robot.finalPosition(input.Grid, second)
+					if _, ok := occupied[final]; ok {
+						continue nextSecond
+					}
+					occupied[final] = struct{}{}
+				}
+				unstacked = append(unstacked, second)
+			}
+
+			if len(unstacked) != 1 {
+				t.Fatalf("%d robots, seed %d: unstacked at %v", robots, seed, unstacked)
+			}
+
+			got, err := Part2(context.Background(), input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != unstacked[0] {
+				t.Errorf("%d robots, seed %d: got %v, want %d", robots, seed, got, unstacked[0])
+			}
+		}
+	}
+}
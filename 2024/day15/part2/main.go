@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"image"
 	"io"
-	"os"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
 )
@@ -283,7 +282,7 @@ func sumValue(g grid) int {
 
 func loadData() (grid, []instruction) {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-15.txt")
+	dataFile, err := utils.OpenInput(15)
 
 	if err != nil {
 		panic(err)
@@ -0,0 +1,33 @@
+package day16
+
+import "image"
+
+var pointUp = image.Point{Y: -1}
+var pointDown = image.Point{Y: 1}
+var pointRight = image.Point{X: 1}
+var pointLeft = image.Point{X: -1}
+
+func (grid *dijkstraGrid) isWall(point image.Point) isWall {
+	if point.X < 0 || point.Y < 0 || point.X >= grid.Width || point.Y >= grid.Height {
+		return true
+	}
+	return grid.Data[point.Y*grid.Width+point.X]
+}
+
+type visit struct {
+	position  image.Point
+	direction image.Point
+}
+
+func debugDirToCompass(d image.Point) string {
+	if d.X > 0 {
+		return "Right"
+	}
+	if d.X < 0 {
+		return "Left"
+	}
+	if d.Y < 0 {
+		return "Up"
+	}
+	return "Down"
+}
@@ -0,0 +1,58 @@
+package day16
+
+import (
+	"bytes"
+	"fmt"
+	utils "tea-cats.co.uk/aoc/2024"
+	"time"
+)
+
+const debug = false
+
+type isWall bool
+type dijkstraGrid struct {
+	Data   []isWall
+	Width  int
+	Height int
+}
+
+// Input is the maze, with the reindeer starting in the bottom left corner
+// facing east, and the end in the top right.
+type Input struct {
+	Grid dijkstraGrid
+}
+
+func LoadData(raw []byte) (Input, error) {
+	defer utils.TimeTrack(time.Now(), "loadData")
+
+	var width, lines int
+	var data []isWall
+
+	for y, line := range bytes.Split(bytes.TrimRight(raw, "\n"), []byte("\n")) {
+		if y > 0 && len(line) != width {
+			return Input{}, fmt.Errorf("row %d is %d long, expected %d", y, len(line), width)
+		}
+
+		for _, c := range line {
+			switch c {
+			case '#':
+				data = append(data, true)
+			default:
+				data = append(data, false)
+			}
+		}
+
+		width = len(line)
+		lines++
+	}
+
+	if width < 3 || lines < 3 {
+		return Input{}, fmt.Errorf("maze is too small (%dx%d)", width, lines)
+	}
+
+	return Input{Grid: dijkstraGrid{
+		Data:   data,
+		Width:  width,
+		Height: lines,
+	}}, nil
+}
@@ -0,0 +1,53 @@
+package day16
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"testing"
+)
+
+// FuzzLoadData checks that the loader only accepts rectangular mazes, and
+// that on small ones part 2 finds seats whenever part 1 finds a route.
+func FuzzLoadData(f *testing.F) {
+	log.SetOutput(io.Discard)
+
+	for _, file := range []string{"testdata/example-1.txt", "testdata/example-2.txt"} {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(raw)
+	}
+	f.Add([]byte("###\n#.#\n###\n"))
+	f.Add([]byte("....\n....\n....\n"))
+	f.Add([]byte("###\n##\n###\n"))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		input, err := LoadData(raw)
+		if err != nil {
+			return
+		}
+
+		grid := input.Grid
+		if grid.Width < 3 || grid.Height < 3 || len(grid.Data) != grid.Width*grid.Height {
+			t.Fatalf("accepted a %dx%d maze with %d tiles", grid.Width, grid.Height, len(grid.Data))
+		}
+		if len(grid.Data) > 400 {
+			return
+		}
+
+		ctx := context.Background()
+		if _, err := Part1(ctx, input); err != nil {
+			return
+		}
+		seats, err := Part2(ctx, input)
+		if err != nil {
+			t.Fatalf("part 1 finds a route, but part 2 fails: %v", err)
+		}
+		if seats.(int) < 1 {
+			t.Fatalf("%d seats on the best routes", seats)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package day16
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	utils "tea-cats.co.uk/aoc/2024"
+	"tea-cats.co.uk/aoc/runner"
+	"time"
+)
+
+// Part1 is the lowest score any path through the maze can get.
+func Part1(ctx context.Context, input Input) (any, error) {
+	return input.Grid.findRoute(ctx)
+}
+
+type history map[visit]struct{}
+
+func (h history) has(i *Item) bool {
+	_, ok := h[visit{i.position, i.direction}]
+
+	return ok
+}
+
+func (grid *dijkstraGrid) findRoute(ctx context.Context) (int, error) {
+	defer utils.TimeTrack(time.Now(), "findRoute")
+
+	start := image.Point{Y: grid.Height - 2}
+	dest := image.Point{X: grid.Width - 2, Y: 1}
+	preStart := &Item{
+		position:     start,
+		direction:    pointRight,
+		costToArrive: 0,
+		priority:     0,
+	}
+
+	if debug {
+		fmt.Printf("Finding path from %v to %v\n", start, dest)
+		fmt.Printf("==========================\n\n")
+	}
+
+	queue := PriorityQueue{length: 0, data: make([]*Item, 128)}
+	queue.put(preStart, pointRight, 0, dest)
+	visited := history{}
+
+	var current *Item
+
+	limit := len(grid.Data)
+	if debug {
+		limit = 20
+	}
+	for x := 0; x < limit; x++ {
+		if x%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			runner.Report(ctx, "expanded %d nodes, %d queued", x, queue.length)
+		}
+
+		queue.sort()
+
+		if debug {
+			fmt.Printf("\n--------------------------------------\n\n")
+			fmt.Println("Locating next node")
+			for i := 0; i < queue.length; i++ {
+				n := queue.data[i]
+				fmt.Printf(" * %3d: %v %s [%d -> %d]\n", i, n.position, debugDirToCompass(n.direction), n.costToArrive, n.priority)
+			}
+		}
+
+		for current = queue.pop(); current != nil && visited.has(current); current = queue.pop() {
+			if debug {
+				fmt.Printf(" - Skipping visited %v:%s\n", current.position, debugDirToCompass(current.direction))
+			}
+		}
+		if current == nil {
+			return 0, errors.New("no route through the maze")
+		}
+		visited[visit{current.position, current.direction}] = struct{}{}
+
+		if debug {
+			fmt.Printf("\nExpanding node %v (facing %s, cost %d)\n", current.position, debugDirToCompass(current.direction), current.costToArrive)
+		}
+
+		if current.position == dest {
+			return current.costToArrive, nil
+		}
+
+		nextPoint := current.position.Add(current.direction)
+		if !grid.isWall(nextPoint) {
+			queue.put(current, current.direction, current.costToArrive+1, dest)
+		}
+
+		if current.direction.X == 0 {
+			// Current N/S, can turn to E/W
+			nextPoint = current.position.Add(pointRight)
+			if !grid.isWall(nextPoint) {
+				queue.put(current, pointRight, current.costToArrive+1001, dest)
+			}
+
+			nextPoint = current.position.Add(pointLeft)
+			if !grid.isWall(nextPoint) {
+				queue.put(current, pointLeft, current.costToArrive+1001, dest)
+			}
+		} else {
+			nextPoint = current.position.Add(pointUp)
+			if !grid.isWall(nextPoint) {
+				queue.put(current, pointUp, current.costToArrive+1001, dest)
+			}
+
+			nextPoint = current.position.Add(pointDown)
+			if !grid.isWall(nextPoint) {
+				queue.put(current, pointDown, current.costToArrive+1001, dest)
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("no route found in %d steps", limit)
+}
@@ -0,0 +1,153 @@
+package day16
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	utils "tea-cats.co.uk/aoc/2024"
+	"tea-cats.co.uk/aoc/runner"
+	"time"
+)
+
+// Part2 counts the tiles which are on at least one of the best paths.
+func Part2(ctx context.Context, input Input) (any, error) {
+	return input.Grid.findSeats(ctx)
+}
+
+func (grid *dijkstraGrid) findSeats(ctx context.Context) (int, error) {
+	defer utils.TimeTrack(time.Now(), "findSeats")
+
+	start := image.Point{Y: grid.Height - 2}
+	dest := image.Point{X: grid.Width - 2, Y: 1}
+	preStart := &Item{
+		position:     start,
+		direction:    pointRight,
+		costToArrive: 0,
+		priority:     0,
+		path:         []image.Point{},
+	}
+
+	if debug {
+		fmt.Printf("Finding path from %v to %v\n", start, dest)
+		fmt.Printf("==========================\n\n")
+	}
+
+	queue := PriorityQueue{length: 0, data: make([]*Item, 128)}
+	queue.put(preStart, pointRight, 0, dest)
+
+	minPathLength := math.MaxInt
+
+	visited := map[visit]int{}
+	seats := map[image.Point]bool{}
+
+	var current *Item
+
+	limit := math.MaxInt
+	if debug {
+		limit = 2000
+	}
+
+	for x := 0; x < limit; x++ {
+		if x%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			runner.Report(ctx, "expanded %d nodes, %d queued, %d seats found", x, queue.length, len(seats))
+		}
+		queue.sort()
+
+		if debug {
+			fmt.Printf("\n--------------------------------------\n\n")
+			fmt.Println("Locating next node")
+			for i := 0; i < queue.length; i++ {
+				n := queue.data[i]
+				fmt.Printf(" * %3d: %v %s [%d -> %d]\n", i, n.position, debugDirToCompass(n.direction), n.costToArrive, n.priority)
+			}
+		}
+
+		for current = queue.pop(); current != nil; current = queue.pop() {
+			previousCost, ok := visited[visit{current.position, current.direction}]
+
+			if ok && previousCost < current.costToArrive {
+				if debug {
+					fmt.Printf(" - Skipping visited %v:%s\n", current.position, debugDirToCompass(current.direction))
+				}
+				continue
+			}
+			break
+		}
+
+		if current == nil {
+			break
+		}
+
+		visited[visit{current.position, current.direction}] = current.costToArrive
+
+		if debug {
+			fmt.Printf("\nExpanding node %v (facing %s, cost %d)\n", current.position, debugDirToCompass(current.direction), current.costToArrive)
+		}
+
+		if current.priority > minPathLength {
+			if debug {
+				fmt.Printf("Discaring too long path %v\n", *current)
+			}
+			continue
+		}
+
+		if current.position == dest {
+			minPathLength = current.costToArrive
+			for _, n := range current.path {
+				seats[n] = true
+			}
+			continue
+		}
+
+		nextPoint := current.position.Add(current.direction)
+		if !grid.isWall(nextPoint) {
+			queue.put(current, current.direction, current.costToArrive+1, dest)
+		}
+
+		if current.direction.X == 0 {
+			// Current N/S, can turn to E/W
+			nextPoint = current.position.Add(pointRight)
+			if !grid.isWall(nextPoint) {
+				queue.put(current, pointRight, current.costToArrive+1001, dest)
+			}
+
+			nextPoint = current.position.Add(pointLeft)
+			if !grid.isWall(nextPoint) {
+				queue.put(current, pointLeft, current.costToArrive+1001, dest)
+			}
+		} else {
+			nextPoint = current.position.Add(pointUp)
+			if !grid.isWall(nextPoint) {
+				queue.put(current, pointUp, current.costToArrive+1001, dest)
+			}
+
+			nextPoint = current.position.Add(pointDown)
+			if !grid.isWall(nextPoint) {
+				queue.put(current, pointDown, current.costToArrive+1001, dest)
+			}
+		}
+	}
+
+	point := image.Point{X: 0, Y: 0}
+
+	if debug {
+		for point.Y = 0; point.Y < grid.Height; point.Y++ {
+			for point.X = 0; point.X < grid.Width; point.X++ {
+				if grid.isWall(point) {
+					fmt.Printf("#")
+				} else if seats[point] {
+					fmt.Printf("O")
+				} else {
+					fmt.Printf(".")
+				}
+			}
+			fmt.Printf("\n")
+		}
+	}
+
+	return len(seats), nil
+}
@@ -0,0 +1,124 @@
+package day16
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"sort"
+	utils "tea-cats.co.uk/aoc/2024"
+)
+
+// An Item is something we manage in a priority queue.
+type Item struct {
+	position     image.Point
+	direction    image.Point
+	costToArrive int
+	priority     int // The priority of the item in the queue.
+	path         []image.Point
+}
+
+// A PriorityQueue  and holds Items.
+type PriorityQueue struct {
+	length   int
+	capacity int
+	data     []*Item
+}
+
+func (pq *PriorityQueue) Len() int { return pq.length }
+
+func (pq *PriorityQueue) Less(i, j int) bool {
+	// We want Pop to give us the lowest, not highest, weight/priority so we use greater than here.
+	itemI, itemJ := pq.data[i], pq.data[j]
+
+	if itemI.priority == itemJ.priority {
+		return itemI.costToArrive > itemJ.costToArrive
+	}
+
+	return itemI.priority > itemJ.priority
+	//return pq.data[i].priority > pq.data[j].priority
+}
+
+func (pq *PriorityQueue) Swap(i, j int) {
+	pq.data[i], pq.data[j] = pq.data[j], pq.data[i]
+}
+
+func (pq *PriorityQueue) pop() *Item {
+	if pq.length == 0 {
+		log.Printf("pop on empty queue\n")
+		return nil
+	}
+
+	pq.length--
+
+	item := pq.data[pq.length]
+	pq.data[pq.length] = nil
+
+	return item
+}
+
+func (pq *PriorityQueue) put(previous *Item, direction image.Point, cost int, dest image.Point) {
+
+	position := previous.position.Add(direction)
+
+	if debug {
+		fmt.Printf(" * Queuing move of %v %s [current cost=%d", debugDirToCompass(direction), position, cost)
+	}
+
+	dist := dest.Sub(position)
+
+	if debug {
+		fmt.Printf(", distance=%v", dist)
+	}
+
+	// Total priority = min total cost
+	//    = current cost + perfect run to target
+	predictedCost := cost
+
+	if dist.X != 0 {
+		// Horizontal moves -- minimum of dist.X, plus 1000 if we're not facing that way
+		predictedCost += utils.Abs(dist.X)
+		if dist.X*direction.X <= 0 {
+			if debug {
+				fmt.Printf(", requires E/W turn")
+			}
+			predictedCost += 1000
+		}
+	}
+
+	if dist.Y != 0 {
+		// Horizontal moves -- minimum of dist.Y, plus 1000 if we're not facing that way
+		predictedCost += utils.Abs(dist.Y)
+		if dist.Y*direction.Y <= 0 {
+			if debug {
+				fmt.Printf(", requires N/S turn")
+			}
+			predictedCost += 1000
+		}
+	}
+
+	if debug {
+		fmt.Printf(", expectation=%d]\n", predictedCost)
+	}
+
+	if pq.length == pq.capacity {
+		newData := make([]*Item, pq.length+128)
+		copy(newData, pq.data)
+		pq.data = newData
+		pq.capacity = len(pq.data)
+	}
+
+	// Only keep track of the path if the caller wants it.
+	var newPath []image.Point
+	if previous.path != nil {
+		newPath = make([]image.Point, len(previous.path)+1)
+		copy(newPath, previous.path)
+		newPath[len(previous.path)] = position
+	}
+
+	pq.data[pq.length] = &Item{position, direction, cost, predictedCost, newPath}
+	pq.length++
+}
+
+func (pq *PriorityQueue) sort() {
+	sort.Sort(pq)
+}
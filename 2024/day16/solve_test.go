@@ -0,0 +1,16 @@
+package day16
+
+import (
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/runner/runnertest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	runnertest.Examples(t, []runnertest.Example{
+		{Name: "part1", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part1), Want: "7036"},
+		{Name: "part1-2", File: "testdata/example-2.txt", Solve: runner.Parsed(LoadData, Part1), Want: "11048"},
+		{Name: "part2", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part2), Want: "45"},
+		{Name: "part2-2", File: "testdata/example-2.txt", Solve: runner.Parsed(LoadData, Part2), Want: "64"},
+	})
+}
@@ -0,0 +1,28 @@
+package day17
+
+import (
+	utils "tea-cats.co.uk/aoc/2024"
+	"tea-cats.co.uk/aoc/2024/day17/vm"
+	"time"
+)
+
+const debug = false
+
+// stepLimit stops programs which never halt. Real inputs run for a few
+// hundred steps.
+const stepLimit = 1_000_000
+
+type Input struct {
+	Program vm.Program
+}
+
+func LoadData(raw []byte) (Input, error) {
+	defer utils.TimeTrack(time.Now(), "loadData")
+
+	program, err := vm.Parse(raw)
+	if err != nil {
+		return Input{}, err
+	}
+
+	return Input{Program: program}, nil
+}
@@ -0,0 +1,17 @@
+package day17
+
+import (
+	"context"
+	"tea-cats.co.uk/aoc/2024/day17/vm"
+)
+
+// Part1 is the output of the program, joined with commas. aoc vm trace
+// shows how the program gets there.
+func Part1(_ context.Context, input Input) (any, error) {
+	output, err := vm.New(input.Program).Run(stepLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return vm.FormatOutput(output), nil
+}
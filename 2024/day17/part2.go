@@ -0,0 +1,135 @@
+package day17
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"tea-cats.co.uk/aoc/2024/day17/vm"
+	"tea-cats.co.uk/aoc/runner"
+)
+
+var (
+	ErrUnsupportedProgram = errors.New("program does not shift A by 3 per output and loop on jnz 0")
+	ErrNoQuine            = errors.New("no value of A makes the program output itself")
+)
+
+// Part2 finds the lowest value of A which makes the program output itself.
+func Part2(ctx context.Context, input Input) (any, error) {
+	if debug {
+		_ = vm.Disassemble(os.Stdout, input.Program)
+	}
+
+	return findQuine(ctx, input.Program)
+}
+
+// checkShape makes sure the program is a single loop, ending with jnz 0,
+// which outputs one value and shifts A right by three bits each time round.
+// The output on each pass then only depends on what is left of A, so A can
+// be built up three bits at a time starting from the last output.
+func checkShape(program vm.Program) error {
+	count := func(match func(vm.Instruction) bool) int {
+		total := 0
+		for _, i := range program.Instructions {
+			if match(i) {
+				total++
+			}
+		}
+		return total
+	}
+
+	last := len(program.Instructions) - 1
+	if last < 0 || program.Instructions[last] != (vm.Instruction{Opcode: vm.JNZ, Operand: 0}) {
+		return fmt.Errorf("%w: the last instruction is not jnz 0", ErrUnsupportedProgram)
+	}
+	if jumps := count(func(i vm.Instruction) bool { return i.Opcode == vm.JNZ }); jumps != 1 {
+		return fmt.Errorf("%w: it has %d jumps", ErrUnsupportedProgram, jumps)
+	}
+	if outputs := count(func(i vm.Instruction) bool { return i.Opcode == vm.OUT }); outputs != 1 {
+		return fmt.Errorf("%w: it has %d outputs per loop", ErrUnsupportedProgram, outputs)
+	}
+	if writes := count(func(i vm.Instruction) bool { return i.Opcode == vm.ADV }); writes != 1 {
+		return fmt.Errorf("%w: it changes A %d times per loop", ErrUnsupportedProgram, writes)
+	}
+	if !slices.Contains(program.Instructions, vm.Instruction{Opcode: vm.ADV, Operand: 3}) {
+		return fmt.Errorf("%w: A is not shifted by exactly 3", ErrUnsupportedProgram)
+	}
+
+	return nil
+}
+
+// findQuine works backwards from the last output. The last pass of the loop
+// only sees the top three bits of A, so each of the eight values they can
+// take is tried; for those which give the right output, the next three bits
+// are tried against the last two outputs, and so on. Trying the smaller
+// values first means the first A that outputs the whole program is the
+// lowest.
+func findQuine(ctx context.Context, program vm.Program) (uint64, error) {
+	if err := checkShape(program); err != nil {
+		return 0, err
+	}
+
+	code := program.Code()
+	if len(code) > 21 {
+		return 0, fmt.Errorf("program is %d values long, so A would need more than 64 bits", len(code))
+	}
+
+	// Every candidate is run, so use the compiled program rather than the
+	// interpreter.
+	compiled := vm.Compile(program)
+	tried := 0
+
+	var search func(a uint64, index int) (uint64, bool, error)
+	search = func(a uint64, index int) (uint64, bool, error) {
+		if index < 0 {
+			return a, true, nil
+		}
+
+		for chunk := range uint64(8) {
+			candidate := a<<3 | chunk
+
+			// Leading zeros would leave A too short to make every output.
+			if candidate == 0 {
+				continue
+			}
+
+			tried++
+			if tried%1024 == 0 {
+				if err := ctx.Err(); err != nil {
+					return 0, false, err
+				}
+				runner.Report(ctx, "tried %d values, matching from output %d", tried, index)
+			}
+
+			output, err := compiled.Run(candidate, stepLimit)
+			if err != nil {
+				return 0, false, err
+			}
+
+			if debug {
+				fmt.Printf("A=%o outputs %s, want %s\n", candidate, vm.FormatOutput(output), vm.FormatOutput(code[index:]))
+			}
+
+			if !slices.Equal(output, code[index:]) {
+				continue
+			}
+
+			if found, ok, err := search(candidate, index-1); err != nil || ok {
+				return found, ok, err
+			}
+		}
+
+		return 0, false, nil
+	}
+
+	a, ok, err := search(0, len(code)-1)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, ErrNoQuine
+	}
+
+	return a, nil
+}
@@ -0,0 +1,27 @@
+package day17
+
+import (
+	"context"
+	"fmt"
+	"tea-cats.co.uk/aoc/2024/day17/symbolic"
+)
+
+// Part2Symbolic runs the program with every bit of A unknown, and solves
+// the equations that outputting the program gives. Unlike Part2, it does not
+// need the program to be of any particular shape.
+func Part2Symbolic(ctx context.Context, input Input) (any, error) {
+	code := input.Program.Code()
+
+	if debug {
+		err := symbolic.Explore(ctx, input.Program, 64, code, func(p symbolic.Path) error {
+			a, ok := p.Min()
+			fmt.Printf("%slowest A on this path: %d (%v)\n\n", p, a, ok)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return symbolic.Min(ctx, input.Program, 64, code)
+}
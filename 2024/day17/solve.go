@@ -0,0 +1,8 @@
+package day17
+
+import "tea-cats.co.uk/aoc/runner"
+
+func init() {
+	runner.RegisterParsed(2024, 17, LoadData, Part1, Part2)
+	runner.RegisterVariant(2024, 17, 2, "symbolic", runner.Parsed(LoadData, Part2Symbolic))
+}
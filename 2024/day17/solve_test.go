@@ -0,0 +1,82 @@
+package day17
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"tea-cats.co.uk/aoc/2024/day17/symbolic"
+	"tea-cats.co.uk/aoc/2024/day17/vm"
+	"tea-cats.co.uk/aoc/2024/generate"
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/runner/runnertest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	runnertest.Examples(t, []runnertest.Example{
+		{Name: "part1", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part1), Want: "4,6,3,5,6,3,5,2,1,0"},
+		{Name: "part2", File: "testdata/example-2.txt", Solve: runner.Parsed(LoadData, Part2), Want: "117440"},
+		{Name: "part2-symbolic", File: "testdata/example-2.txt", Solve: runner.Parsed(LoadData, Part2Symbolic), Want: "117440"},
+	})
+}
+
+// TestQuineGenerated checks that the quine found for each generated program
+// outputs the program, and is the lowest A that does according to the
+// symbolic solver, which does not share findQuine's search. Every generated
+// program has a quine, so none are skipped.
+func TestQuineGenerated(t *testing.T) {
+	for seed := uint64(1); seed <= 100; seed++ {
+		input, err := LoadData(generate.Day17(generate.NewRand(seed), 10))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a, err := findQuine(context.Background(), input.Program)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+
+		output, err := vm.Run(input.Program, a, stepLimit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(output, input.Program.Code()) {
+			t.Errorf("seed %d: A=%d outputs %s, not %s", seed, a, vm.FormatOutput(output), input.Program)
+		}
+
+		lowest, err := symbolic.Min(context.Background(), input.Program, 64, input.Program.Code())
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if a != lowest {
+			t.Errorf("seed %d: found A=%d, but %d is lower", seed, a, lowest)
+		}
+	}
+}
+
+func TestQuineUnsupported(t *testing.T) {
+	raw, err := os.ReadFile("testdata/example-1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input, err := LoadData(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Part2(context.Background(), input); !errors.Is(err, ErrUnsupportedProgram) {
+		t.Errorf("got %v, want %v", err, ErrUnsupportedProgram)
+	}
+}
+
+func TestVariants(t *testing.T) {
+	for seed := uint64(1); seed <= 100; seed++ {
+		raw := generate.Day17(generate.NewRand(seed), 10)
+
+		if _, err := runner.Compare(context.Background(), runner.Puzzle{Year: 2024, Day: 17, Part: 2}, raw); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package symbolic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tea-cats.co.uk/aoc/2024/day17/vm"
+)
+
+var ErrLimit = errors.New("search limit reached")
+
+// Paths are cut short as soon as they output something other than the
+// target, so the limits only matter for loops that do not output anything,
+// which can fork without end. stepLimit is how many instructions a single
+// path may run, and stateLimit how many steps all of them may take together.
+const (
+	stepLimit  = 10_000
+	stateLimit = 1_000_000
+)
+
+// Constraint is one equation a path depends on, with the reason for it.
+type Constraint struct {
+	Expr   Expr
+	Value  bool
+	Reason string
+}
+
+func (c Constraint) String() string {
+	return fmt.Sprintf("%s = %d  (%s)", c.Expr, map[bool]int{false: 0, true: 1}[c.Value], c.Reason)
+}
+
+// Clause says that at least one of its bits is set, which is what a jump
+// being taken, or a shift of 64 or more, needs. These are not linear, so
+// are checked by the solver once it has picked values.
+type Clause struct {
+	Exprs  []Expr
+	Reason string
+}
+
+func (c Clause) holds(a uint64) bool {
+	for _, e := range c.Exprs {
+		if e.Eval(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// Path is one way through the program which produces the target output.
+type Path struct {
+	Width int
+
+	// Outputs is the three bits of each value output, least significant first.
+	Outputs [][3]Expr
+
+	// Constraints are the equations for each fork and output, in the order
+	// they were found; System is the same equations, solved as far as they go.
+	Constraints []Constraint
+	System      System
+	Clauses     []Clause
+}
+
+type state struct {
+	a, b, c Word
+	ip      int
+	steps   int
+	path    Path
+}
+
+func (s *state) clone() *state {
+	clone := *s
+	clone.path.Outputs = append([][3]Expr(nil), s.path.Outputs...)
+	clone.path.Constraints = append([]Constraint(nil), s.path.Constraints...)
+	clone.path.Clauses = append([]Clause(nil), s.path.Clauses...)
+	return &clone
+}
+
+// require adds the equation e = value to the path, returning false if the
+// path can no longer happen. Equations which follow from the ones already
+// there are left out of the constraints.
+func (s *state) require(e Expr, value bool, reason string) bool {
+	if reduced := s.path.System.Reduce(e); reduced.Vars == 0 {
+		return reduced.Const == value
+	}
+
+	s.path.System.Add(e, value)
+	s.path.Constraints = append(s.path.Constraints, Constraint{Expr: e, Value: value, Reason: reason})
+	return true
+}
+
+// requireAny adds a clause that one of exprs is set, returning false if the
+// equations already rule that out.
+func (s *state) requireAny(exprs []Expr, reason string) bool {
+	clause := Clause{Reason: reason}
+
+	for _, e := range exprs {
+		reduced := s.path.System.Reduce(e)
+		if reduced.Vars == 0 && reduced.Const {
+			return true
+		}
+		if reduced.Vars != 0 {
+			clause.Exprs = append(clause.Exprs, e)
+		}
+	}
+
+	if len(clause.Exprs) == 0 {
+		return false
+	}
+
+	s.path.Clauses = append(s.path.Clauses, clause)
+	return true
+}
+
+func (s *state) combo(operand uint8) Word {
+	switch operand {
+	case 4:
+		return s.a
+	case 5:
+		return s.b
+	case 6:
+		return s.c
+	}
+	return constWord(uint64(operand))
+}
+
+// Explore runs the program with the lowest width bits of A unknown (and the
+// rest zero), calling visit for each path which outputs exactly target.
+// B and C start with the values given in the program.
+func Explore(ctx context.Context, program vm.Program, width int, target []uint8, visit func(Path) error) error {
+	if width < 1 || width > 64 {
+		return fmt.Errorf("width %d is not between 1 and 64", width)
+	}
+
+	start := &state{
+		a:    varWord(width),
+		b:    constWord(program.Registers.B),
+		c:    constWord(program.Registers.C),
+		path: Path{Width: width},
+	}
+
+	// Depth first, so only one path per fork is waiting at a time.
+	pending := []*state{start}
+	explored := 0
+
+	for len(pending) > 0 {
+		explored++
+		if explored > stateLimit {
+			return fmt.Errorf("%w after %d steps", ErrLimit, stateLimit)
+		}
+		if explored%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if current.ip < 0 || current.ip >= len(program.Instructions) {
+			if len(current.path.Outputs) != len(target) {
+				continue
+			}
+			if err := visit(current.path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		next, err := step(current, program.Instructions[current.ip], target)
+		if err != nil {
+			return fmt.Errorf("at %d: %w", 2*current.ip, err)
+		}
+		pending = append(pending, next...)
+	}
+
+	return nil
+}
+
+// step runs one instruction, returning the states it can lead to. There can
+// be none, if the path turns out to be impossible or outputs the wrong thing.
+func step(s *state, i vm.Instruction, target []uint8) ([]*state, error) {
+	if err := i.Valid(); err != nil {
+		return nil, err
+	}
+
+	s.steps++
+	if s.steps > stepLimit {
+		return nil, fmt.Errorf("%w: a path ran %d steps", ErrLimit, stepLimit)
+	}
+
+	switch i.Opcode {
+	case vm.ADV, vm.BDV, vm.CDV:
+		return shift(s, i)
+	case vm.BXL:
+		s.b = s.b.xor(constWord(uint64(i.Operand)))
+	case vm.BST:
+		s.b = s.combo(i.Operand).low3()
+	case vm.JNZ:
+		return jump(s, i)
+	case vm.BXC:
+		s.b = s.b.xor(s.c)
+	case vm.OUT:
+		index := len(s.path.Outputs)
+		if index >= len(target) {
+			return nil, nil
+		}
+
+		value := s.combo(i.Operand)
+		reason := fmt.Sprintf("output %d is %d", index, target[index])
+		for bit := range 3 {
+			if !s.require(value[bit], target[index]&(1<<bit) != 0, reason) {
+				return nil, nil
+			}
+		}
+
+		s.path.Outputs = append(s.path.Outputs, [3]Expr{value[0], value[1], value[2]})
+	}
+
+	s.ip++
+	return []*state{s}, nil
+}
+
+// jump forks into the paths where A is zero and the program carries on, and
+// where it is not and the jump is taken.
+func jump(s *state, i vm.Instruction) ([]*state, error) {
+	if i.Operand%2 != 0 {
+		return nil, fmt.Errorf("%w %d", vm.ErrOddJump, i.Operand)
+	}
+
+	next := make([]*state, 0, 2)
+
+	zero := s.clone()
+	possible := true
+	for _, e := range s.a {
+		if possible = zero.require(e, false, "A is zero at the jump"); !possible {
+			break
+		}
+	}
+	if possible {
+		zero.ip++
+		next = append(next, zero)
+	}
+
+	if s.requireAny(s.a[:], "A is not zero at the jump") {
+		s.ip = int(i.Operand / 2)
+		next = append(next, s)
+	}
+
+	return next, nil
+}
+
+// shift forks for every amount the shift could be by. If all of the amount
+// is known, there is only one; otherwise each unknown bit of the bottom six
+// doubles the count, and any unknown higher bits add one more path where the
+// shift is by 64 or more, so that the result is zero.
+func shift(s *state, i vm.Instruction) ([]*state, error) {
+	amount := s.combo(i.Operand)
+	next := make([]*state, 0)
+
+	apply := func(s *state, by int) {
+		result := Word{}
+		if by < 64 {
+			result = s.a.shiftRight(by)
+		}
+
+		switch i.Opcode {
+		case vm.ADV:
+			s.a = result
+		case vm.BDV:
+			s.b = result
+		case vm.CDV:
+			s.c = result
+		}
+
+		s.ip++
+		next = append(next, s)
+	}
+
+	high := make([]Expr, 0)
+	for _, e := range amount[6:] {
+		reduced := s.path.System.Reduce(e)
+		if reduced.Vars == 0 && reduced.Const {
+			apply(s, 64)
+			return next, nil
+		}
+		if reduced.Vars != 0 {
+			high = append(high, e)
+		}
+	}
+
+	if len(high) > 0 {
+		large := s.clone()
+		if large.requireAny(high, fmt.Sprintf("%s shifts by 64 or more", i.Opcode)) {
+			apply(large, 64)
+		}
+
+		for _, e := range high {
+			if !s.require(e, false, fmt.Sprintf("%s shifts by less than 64", i.Opcode)) {
+				return next, nil
+			}
+		}
+	}
+
+	// The bits of the amount which are still unknown once the equations so
+	// far are taken into account.
+	known := 0
+	unknown := make([]int, 0, 6)
+	for bit, e := range amount[:6] {
+		reduced := s.path.System.Reduce(e)
+		switch {
+		case reduced.Vars != 0:
+			unknown = append(unknown, bit)
+		case reduced.Const:
+			known |= 1 << bit
+		}
+	}
+
+	for choice := range 1 << len(unknown) {
+		fork := s
+		if len(unknown) > 0 {
+			fork = s.clone()
+		}
+
+		by := known
+		for n, bit := range unknown {
+			by |= (choice >> n & 1) << bit
+		}
+
+		reason := fmt.Sprintf("%s shifts by %d", i.Opcode, by)
+		possible := true
+		for _, bit := range unknown {
+			if possible = fork.require(amount[bit], by&(1<<bit) != 0, reason); !possible {
+				break
+			}
+		}
+
+		if possible {
+			apply(fork, by)
+		}
+	}
+
+	return next, nil
+}
@@ -0,0 +1,104 @@
+// Package symbolic runs day 17 programs with register A unknown, to work out
+// which values of A give a particular output.
+//
+// Every instruction except the shifts by a register is linear over bits: xor
+// combines bits, masking and shifting by a constant just move them around.
+// So each bit of each register can be kept as the xor of some of the bits of
+// the starting value of A, and each output gives three linear equations that
+// those bits have to satisfy. Shifting by a register, and jumping, depend on
+// the actual values, so execution forks into one path for each possibility,
+// with the equations that pick it out. Each path that produces the whole
+// target output leaves a system of equations, which the solver turns back
+// into values of A.
+package symbolic
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Expr is one bit: the xor of the bits of A in Vars, and Const.
+type Expr struct {
+	Vars  uint64
+	Const bool
+}
+
+func constExpr(value bool) Expr {
+	return Expr{Const: value}
+}
+
+func varExpr(bit int) Expr {
+	return Expr{Vars: 1 << bit}
+}
+
+func (e Expr) Xor(other Expr) Expr {
+	return Expr{Vars: e.Vars ^ other.Vars, Const: e.Const != other.Const}
+}
+
+// IsConst reports whether the bit is the same whatever A is, and its value.
+func (e Expr) IsConst() (bool, bool) {
+	return e.Vars == 0, e.Const
+}
+
+// Eval is the value of the bit when A is a.
+func (e Expr) Eval(a uint64) bool {
+	return (bits.OnesCount64(e.Vars&a)%2 == 1) != e.Const
+}
+
+func (e Expr) String() string {
+	terms := make([]string, 0, bits.OnesCount64(e.Vars)+1)
+
+	for v := e.Vars; v != 0; v &= v - 1 {
+		terms = append(terms, "a"+strconv.Itoa(bits.TrailingZeros64(v)))
+	}
+	if e.Const || len(terms) == 0 {
+		terms = append(terms, map[bool]string{false: "0", true: "1"}[e.Const])
+	}
+
+	return strings.Join(terms, " ^ ")
+}
+
+// Word is a register, least significant bit first.
+type Word [64]Expr
+
+func constWord(value uint64) Word {
+	w := Word{}
+	for i := range w {
+		w[i] = constExpr(value&(1<<i) != 0)
+	}
+	return w
+}
+
+// varWord is A at the start: the first width bits are unknown, and the rest
+// are zero.
+func varWord(width int) Word {
+	w := Word{}
+	for i := range width {
+		w[i] = varExpr(i)
+	}
+	return w
+}
+
+func (w Word) xor(other Word) Word {
+	for i := range w {
+		w[i] = w[i].Xor(other[i])
+	}
+	return w
+}
+
+// shiftRight moves the bits down by n, filling the top with zeros.
+func (w Word) shiftRight(n int) Word {
+	result := Word{}
+	for i := 0; i+n < len(w); i++ {
+		result[i] = w[i+n]
+	}
+	return result
+}
+
+// low3 keeps only the bottom three bits, as bst and out do.
+func (w Word) low3() Word {
+	result := Word{}
+	copy(result[:3], w[:3])
+	return result
+}
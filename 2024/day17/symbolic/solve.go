@@ -0,0 +1,161 @@
+package symbolic
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"tea-cats.co.uk/aoc/2024/day17/vm"
+)
+
+var ErrNoSolution = errors.New("no value of A gives the target output")
+
+// errEnough stops the search once enough solutions have been found.
+var errEnough = errors.New("enough solutions")
+
+// solve picks values for the bits of A from the most significant down,
+// trying 0 before 1, so solutions are found in increasing order. Each
+// choice is added to the equations, which cuts off any branch that
+// contradicts them straight away. Clauses are checked once all their bits
+// have been picked. found is called for each solution, and can stop the
+// search by returning an error.
+func (p Path) solve(found func(a uint64) error) error {
+	// The lowest bit of each clause, so it can be checked as soon as the
+	// search gets below it.
+	lowest := make([]int, len(p.Clauses))
+	for i, clause := range p.Clauses {
+		lowest[i] = 64
+		for _, e := range clause.Exprs {
+			for bit := range 64 {
+				if e.Vars&(1<<bit) != 0 {
+					lowest[i] = min(lowest[i], bit)
+					break
+				}
+			}
+		}
+	}
+
+	var search func(system System, bit int, a uint64) error
+	search = func(system System, bit int, a uint64) error {
+		for i, clause := range p.Clauses {
+			if lowest[i] == bit+1 && !clause.holds(a) {
+				return nil
+			}
+		}
+
+		if bit < 0 {
+			return found(a)
+		}
+
+		for _, value := range []bool{false, true} {
+			next := system
+			if !next.Add(varExpr(bit), value) {
+				continue
+			}
+
+			choice := a
+			if value {
+				choice |= 1 << bit
+			}
+			if err := search(next, bit-1, choice); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return search(p.System, p.Width-1, 0)
+}
+
+// Min is the lowest value of A which takes this path.
+func (p Path) Min() (uint64, bool) {
+	var result uint64
+	ok := false
+
+	_ = p.solve(func(a uint64) error {
+		result, ok = a, true
+		return errEnough
+	})
+
+	return result, ok
+}
+
+// All returns the values of A which take this path, lowest first, stopping
+// after limit of them.
+func (p Path) All(limit int) []uint64 {
+	results := make([]uint64, 0)
+
+	_ = p.solve(func(a uint64) error {
+		results = append(results, a)
+		if len(results) >= limit {
+			return errEnough
+		}
+		return nil
+	})
+
+	return results
+}
+
+// String lists the constraints on the path, then the bits of A they decide.
+func (p Path) String() string {
+	var builder strings.Builder
+
+	for _, c := range p.Constraints {
+		builder.WriteString(c.String() + "\n")
+	}
+	for _, c := range p.Clauses {
+		terms := make([]string, len(c.Exprs))
+		for i, e := range c.Exprs {
+			terms[i] = "(" + e.String() + ")"
+		}
+		builder.WriteString(strings.Join(terms, " | ") + " = 1  (" + c.Reason + ")\n")
+	}
+	builder.WriteString("A = " + p.System.Format(p.Width) + "\n")
+
+	return builder.String()
+}
+
+// Min finds the lowest value of A, at most width bits long, which makes the
+// program output target.
+func Min(ctx context.Context, program vm.Program, width int, target []uint8) (uint64, error) {
+	var best uint64
+	found := false
+
+	err := Explore(ctx, program, width, target, func(p Path) error {
+		if a, ok := p.Min(); ok && (!found || a < best) {
+			best, found = a, true
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, ErrNoSolution
+	}
+
+	return best, nil
+}
+
+// All finds every value of A, at most width bits long, which makes the
+// program output target, lowest first. It stops after limit of them, though
+// as the paths are searched one after another, they will not always be the
+// lowest ones.
+func All(ctx context.Context, program vm.Program, width int, target []uint8, limit int) ([]uint64, error) {
+	results := make([]uint64, 0)
+
+	err := Explore(ctx, program, width, target, func(p Path) error {
+		results = append(results, p.All(limit-len(results))...)
+		if len(results) >= limit {
+			return errEnough
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errEnough) {
+		return nil, err
+	}
+
+	slices.Sort(results)
+	return slices.Compact(results), nil
+}
@@ -0,0 +1,80 @@
+package symbolic
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"tea-cats.co.uk/aoc/2024/day17/vm"
+	"testing"
+)
+
+// randomProgram makes a short valid program. Most of them do not halt for
+// every A, which is fine as both sides of the comparison cut them off.
+func randomProgram(rng *rand.Rand) vm.Program {
+	program := vm.Program{Registers: vm.Registers{B: rng.Uint64N(8), C: rng.Uint64N(8)}}
+	length := 2 + rng.IntN(6)
+
+	for range length {
+		i := vm.Instruction{Opcode: vm.Opcode(rng.IntN(8)), Operand: uint8(rng.IntN(8))}
+		if i.Opcode.UsesCombo() {
+			i.Operand = uint8(rng.IntN(7))
+		}
+		if i.Opcode == vm.JNZ {
+			i.Operand = uint8(2 * rng.IntN(min(length, 4)))
+		}
+		program.Instructions = append(program.Instructions, i)
+	}
+
+	return program
+}
+
+// TestAgainstInterpreter checks that, for random programs, the values of A
+// found symbolically are exactly the ones which give the same output when
+// run.
+func TestAgainstInterpreter(t *testing.T) {
+	const width = 9
+	rng := rand.New(rand.NewPCG(17, 0))
+	checked := 0
+
+	for range 2000 {
+		program := randomProgram(rng)
+
+		output, err := vm.Run(program, rng.Uint64N(1<<width), 1000)
+		if err != nil || len(output) > 8 {
+			continue
+		}
+
+		want := make([]uint64, 0)
+		for a := range uint64(1 << width) {
+			if got, err := vm.Run(program, a, 1000); err == nil && slices.Equal(got, output) {
+				want = append(want, a)
+			}
+		}
+
+		got, err := All(context.Background(), program, width, output, 1<<width)
+		if errors.Is(err, ErrLimit) {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", program, err)
+		}
+
+		if !slices.Equal(got, want) {
+			t.Fatalf("program %s with output %s: got A in %v, want %v", program, vm.FormatOutput(output), got, want)
+		}
+
+		if len(want) > 0 {
+			minimum, err := Min(context.Background(), program, width, output)
+			if err != nil || minimum != want[0] {
+				t.Fatalf("program %s with output %s: got minimum %d (%v), want %d", program, vm.FormatOutput(output), minimum, err, want[0])
+			}
+		}
+
+		checked++
+	}
+
+	if checked < 500 {
+		t.Errorf("only %d programs could be checked", checked)
+	}
+}
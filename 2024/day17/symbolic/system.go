@@ -0,0 +1,80 @@
+package symbolic
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// row is the equation that the bits of A in mask xor to value.
+type row struct {
+	mask  uint64
+	value bool
+}
+
+// System is a set of linear equations over the bits of A, kept in echelon
+// form: each row is stored under its highest bit, which no other row has as
+// its highest bit. A System is a value, so copying it keeps the old one as it
+// was, which is what the forks and the solver want.
+type System struct {
+	rows [64]row
+}
+
+// Reduce rewrites e using the equations, so that the only bits left in it
+// are ones that the system does not pin down. If no bits are left, the
+// system decides the value of e.
+func (s *System) Reduce(e Expr) Expr {
+	// Using a row only changes the bits below its highest one, so going from
+	// the top down visits each bit once.
+	for bit := 63 - bits.LeadingZeros64(e.Vars); bit >= 0; bit-- {
+		if r := s.rows[bit]; e.Vars&(1<<bit) != 0 && r.mask != 0 {
+			e = Expr{Vars: e.Vars ^ r.mask, Const: e.Const != r.value}
+		}
+	}
+	return e
+}
+
+// Add adds the equation e = value, returning false if it contradicts the
+// equations already there, in which case the system is left unchanged.
+func (s *System) Add(e Expr, value bool) bool {
+	e = s.Reduce(Expr{Vars: e.Vars, Const: e.Const != value})
+	if e.Vars == 0 {
+		return !e.Const
+	}
+
+	top := 63 - bits.LeadingZeros64(e.Vars)
+	s.rows[top] = row{mask: e.Vars, value: e.Const}
+	return true
+}
+
+// Known returns the bits whose values the equations decide, and those values.
+func (s *System) Known() (mask uint64, values uint64) {
+	for bit := range 64 {
+		e := s.Reduce(varExpr(bit))
+		if e.Vars == 0 {
+			mask |= 1 << bit
+			if e.Const {
+				values |= 1 << bit
+			}
+		}
+	}
+	return mask, values
+}
+
+// Format shows the lowest width bits of A, most significant first, as 0 or 1
+// where the system decides them and _ where it does not.
+func (s *System) Format(width int) string {
+	mask, values := s.Known()
+
+	var builder strings.Builder
+	for bit := width - 1; bit >= 0; bit-- {
+		switch {
+		case mask&(1<<bit) == 0:
+			builder.WriteByte('_')
+		case values&(1<<bit) == 0:
+			builder.WriteByte('0')
+		default:
+			builder.WriteByte('1')
+		}
+	}
+	return builder.String()
+}
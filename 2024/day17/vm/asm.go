@@ -0,0 +1,212 @@
+package vm
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// String is the instruction in the assembler's syntax, such as `adv 3` or
+// `out b`.
+func (i Instruction) String() string {
+	switch {
+	case i.Opcode == BXC && i.Operand == 0:
+		return "bxc"
+	case i.Opcode.UsesCombo() && i.Operand >= 4 && i.Operand <= 6:
+		return i.Opcode.String() + " " + strings.ToLower(comboNames[i.Operand])
+	}
+	return fmt.Sprintf("%s %d", i.Opcode, i.Operand)
+}
+
+// Assemble reads a program written as one instruction per line, such as
+// `bxl 5` or `out b`. Combo operands are 0 to 3 or a register; bxl and jnz
+// take a literal 0 to 7, and bxc's operand, which it ignores, can be left
+// out. A line can start with a label, `name:`, which jnz can use as its
+// operand. The starting registers can be set with `.a 729` and so on.
+// Anything after a # is a comment.
+func Assemble(source string) (Program, error) {
+	type jump struct {
+		line  int
+		index int
+		label string
+	}
+
+	program := Program{}
+	labels := make(map[string]int)
+	jumps := make([]jump, 0)
+
+	scanner := bufio.NewScanner(strings.NewReader(source))
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(text)
+
+		if label, rest, found := strings.Cut(text, ":"); found {
+			label = strings.ToLower(strings.TrimSpace(label))
+			if !validLabel(label) {
+				return Program{}, fmt.Errorf("line %d: invalid label %q", line, label)
+			}
+			if _, exists := labels[label]; exists {
+				return Program{}, fmt.Errorf("line %d: label %q defined twice", line, label)
+			}
+			labels[label] = 2 * len(program.Instructions)
+			text = strings.TrimSpace(rest)
+		}
+
+		fields := strings.Fields(strings.ToLower(text))
+		if len(fields) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(fields[0], ".") {
+			if err := directive(&program, fields); err != nil {
+				return Program{}, fmt.Errorf("line %d: %w", line, err)
+			}
+			continue
+		}
+
+		opcode := slices.Index(mnemonics[:], fields[0])
+		if opcode < 0 {
+			return Program{}, fmt.Errorf("line %d: unknown instruction %q", line, fields[0])
+		}
+		i := Instruction{Opcode: Opcode(opcode)}
+
+		switch {
+		case len(fields) == 1 && i.Opcode == BXC:
+		case len(fields) != 2:
+			return Program{}, fmt.Errorf("line %d: %s takes one operand", line, i.Opcode)
+		case i.Opcode == JNZ && validLabel(fields[1]):
+			jumps = append(jumps, jump{line: line, index: len(program.Instructions), label: fields[1]})
+		default:
+			operand, err := parseOperand(i.Opcode, fields[1])
+			if err != nil {
+				return Program{}, fmt.Errorf("line %d: %w", line, err)
+			}
+			i.Operand = operand
+		}
+
+		program.Instructions = append(program.Instructions, i)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Program{}, err
+	}
+
+	for _, j := range jumps {
+		target, ok := labels[j.label]
+		if !ok {
+			return Program{}, fmt.Errorf("line %d: undefined label %q", j.line, j.label)
+		}
+		if target > 7 {
+			return Program{}, fmt.Errorf("line %d: label %q is at %d, which jnz cannot reach", j.line, j.label, target)
+		}
+		program.Instructions[j.index].Operand = uint8(target)
+	}
+
+	if len(program.Instructions) == 0 {
+		return Program{}, fmt.Errorf("no instructions")
+	}
+
+	return program, nil
+}
+
+// validLabel checks for a name that cannot be mistaken for an operand.
+func validLabel(label string) bool {
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		return false
+	}
+	for _, c := range label {
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func directive(program *Program, fields []string) error {
+	if len(fields) != 2 {
+		return fmt.Errorf("%s takes one value", fields[0])
+	}
+
+	value, err := strconv.ParseUint(fields[1], 0, 64)
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s", fields[1], fields[0])
+	}
+
+	switch fields[0] {
+	case ".a":
+		program.Registers.A = value
+	case ".b":
+		program.Registers.B = value
+	case ".c":
+		program.Registers.C = value
+	default:
+		return fmt.Errorf("unknown directive %s", fields[0])
+	}
+
+	return nil
+}
+
+func parseOperand(opcode Opcode, text string) (uint8, error) {
+	if opcode.UsesCombo() {
+		if register := strings.Index("abc", text); len(text) == 1 && register >= 0 {
+			return uint8(4 + register), nil
+		}
+	}
+
+	value, err := strconv.ParseUint(text, 10, 8)
+	switch {
+	case err != nil && opcode.UsesCombo():
+		return 0, fmt.Errorf("%s takes 0 to 3 or a register, not %q", opcode, text)
+	case err != nil:
+		return 0, fmt.Errorf("%s takes a literal 0 to 7, not %q", opcode, text)
+	case value > 7:
+		return 0, fmt.Errorf("%s operand %d does not fit in three bits", opcode, value)
+	case opcode.UsesCombo() && value == 7:
+		return 0, fmt.Errorf("%s uses reserved combo operand 7", opcode)
+	case opcode.UsesCombo() && value >= 4:
+		// Allowing both would mean two ways to write the same program.
+		return 0, fmt.Errorf("%s combo operand %d is written %s", opcode, value, strings.ToLower(comboNames[value]))
+	}
+
+	return uint8(value), nil
+}
+
+// Source writes out a program so that Assemble gives exactly the same one
+// back, with the starting registers, a label for each jump target, and what
+// each instruction does as a comment.
+func Source(program Program) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, ".a %d\n.b %d\n.c %d\n\n", program.Registers.A, program.Registers.B, program.Registers.C)
+
+	// Jumps past the end of the program just halt, so are left as numbers.
+	labels := make(map[int]string)
+	for _, i := range program.Instructions {
+		if i.Opcode == JNZ && int(i.Operand) < 2*len(program.Instructions) && i.Operand%2 == 0 {
+			labels[int(i.Operand)] = fmt.Sprintf("l%d", i.Operand)
+		}
+	}
+
+	for index, i := range program.Instructions {
+		if label, ok := labels[2*index]; ok {
+			builder.WriteString(label + ":\n")
+		}
+
+		text := i.String()
+		if label, ok := labels[int(i.Operand)]; ok && i.Opcode == JNZ {
+			text = "jnz " + label
+		}
+
+		fmt.Fprintf(&builder, "    %-10s # %02d  %s\n", text, 2*index, i.Explain())
+	}
+
+	return builder.String()
+}
+
+// Format writes the program in the same form as the puzzle input.
+func Format(program Program) string {
+	return fmt.Sprintf("Register A: %d\nRegister B: %d\nRegister C: %d\n\nProgram: %s\n",
+		program.Registers.A, program.Registers.B, program.Registers.C, program)
+}
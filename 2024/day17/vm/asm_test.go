@@ -0,0 +1,116 @@
+package vm
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAssemble(t *testing.T) {
+	source := `
+.a 2024
+
+loop:
+    adv 3       # A = A >> 3
+    out a
+    jnz loop
+`
+	program, err := Assemble(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := Format(program), "Register A: 2024\nRegister B: 0\nRegister C: 0\n\nProgram: 0,3,5,4,3,0\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAssembleErrors(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"adv 7", "reserved combo operand 7"},
+		{"out 5", "is written b"},
+		{"bxl b", "takes a literal"},
+		{"bst 8", "does not fit"},
+		{"jnz end", "undefined label"},
+		{"mul 3", "unknown instruction"},
+		{"adv", "takes one operand"},
+		{"x: adv 1\nx: adv 1", "defined twice"},
+		{"adv 1\nadv 1\nadv 1\nadv 1\nadv 1\nfar: jnz far", "cannot reach"},
+		{".d 4\nadv 1", "unknown directive"},
+		{"# nothing", "no instructions"},
+	}
+
+	for _, test := range tests {
+		_, err := Assemble(test.source)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%q: got error %v, want %q", test.source, err, test.want)
+		}
+	}
+}
+
+// TestRoundTrip checks that disassembling and then assembling any valid
+// program gives back exactly the same code.
+func TestRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewPCG(45, 0))
+
+	for range 1000 {
+		code := make([]string, 2*(1+rng.IntN(8)))
+		for i := 0; i < len(code); i += 2 {
+			opcode := Opcode(rng.IntN(8))
+			operand := rng.IntN(8)
+			if opcode.UsesCombo() {
+				operand = rng.IntN(7)
+			}
+			code[i], code[i+1] = string(rune('0'+opcode)), string(rune('0'+operand))
+		}
+
+		instructions, err := ParseCode(strings.Join(code, ","))
+		if err != nil {
+			t.Fatal(err)
+		}
+		program := Program{Registers: Registers{A: rng.Uint64(), B: rng.Uint64N(8), C: 0}, Instructions: instructions}
+
+		source := Source(program)
+		assembled, err := Assemble(source)
+		if err != nil {
+			t.Fatalf("%s: %v\n%s", program, err, source)
+		}
+
+		if assembled.Registers != program.Registers || !slices.Equal(assembled.Instructions, program.Instructions) {
+			t.Fatalf("%s assembles back to %s\n%s", program, assembled, source)
+		}
+	}
+}
+
+// FuzzAssemble checks that Assemble either rejects its source or gives a
+// program which Source writes back out as the same program.
+func FuzzAssemble(f *testing.F) {
+	f.Add(".a 2024\n\nloop:\n    adv 3       # A = A >> 3\n    out a\n    jnz loop\n")
+	f.Add("bst a\nbxl 1\ncdv b\nbxc\nbxl 4\nout b\nadv 3\njnz 0\n")
+	f.Add("x: adv 1\nx: adv 1")
+	f.Add("adv 1\nadv 1\nadv 1\nadv 1\nadv 1\nfar: jnz far")
+	f.Add(".b 0x10\n.c 7\nout 7\n")
+
+	f.Fuzz(func(t *testing.T, source string) {
+		program, err := Assemble(source)
+		if err != nil {
+			return
+		}
+
+		again, err := Assemble(Source(program))
+		if err != nil {
+			t.Fatalf("%s: %v\n%s", program, err, Source(program))
+		}
+		if again.Registers != program.Registers || !slices.Equal(again.Instructions, program.Instructions) {
+			t.Fatalf("%s assembles back to %s\n%s", program, again, Source(program))
+		}
+
+		if _, err := Parse([]byte(Format(program))); err != nil {
+			t.Fatalf("%q does not parse: %v", Format(program), err)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package vm
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// randomProgram makes a program of up to eight instructions. Unless valid is
+// set, it can include reserved combo operands and odd jumps.
+func randomProgram(rng *rand.Rand, valid bool) Program {
+	instructions := make([]Instruction, 1+rng.IntN(8))
+	for i := range instructions {
+		instructions[i] = Instruction{Opcode: Opcode(rng.IntN(8)), Operand: uint8(rng.IntN(8))}
+		if !valid {
+			continue
+		}
+		switch {
+		case instructions[i].Opcode.UsesCombo():
+			instructions[i].Operand = uint8(rng.IntN(7))
+		case instructions[i].Opcode == JNZ:
+			instructions[i].Operand = uint8(2 * rng.IntN(5))
+		}
+	}
+
+	return Program{Registers: Registers{B: rng.Uint64N(8), C: rng.Uint64N(8)}, Instructions: instructions}
+}
+
+// TestCompiledMatches checks that compiled programs output the same as the
+// interpreter, and fail in the same way.
+func TestCompiledMatches(t *testing.T) {
+	rng := rand.New(rand.NewPCG(46, 0))
+
+	for range 2000 {
+		program := randomProgram(rng, rng.IntN(4) != 0)
+		compiled := Compile(program)
+
+		for range 10 {
+			a := rng.Uint64() >> rng.IntN(64)
+
+			want, wantErr := Run(program, a, 1000)
+			got, gotErr := compiled.Run(a, 1000)
+
+			if fmt.Sprint(gotErr) != fmt.Sprint(wantErr) {
+				t.Fatalf("%s with A=%d: compiled gives error %v, interpreter %v", program, a, gotErr, wantErr)
+			}
+			if !slices.Equal(got, want) {
+				t.Fatalf("%s with A=%d: compiled outputs %s, interpreter %s", program, a, FormatOutput(got), FormatOutput(want))
+			}
+		}
+	}
+}
+
+// TestGoSource builds the generated functions for some random programs, and
+// checks they output the same as the interpreter.
+func TestGoSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a Go program")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+
+	rng := rand.New(rand.NewPCG(46, 1))
+
+	var source, calls, want strings.Builder
+	source.WriteString("package main\n\nimport \"fmt\"\n\n")
+
+	for n := 0; n < 50; {
+		program := randomProgram(rng, true)
+		a := rng.Uint64() >> rng.IntN(64)
+
+		// The generated code has no step limit, so only use programs which
+		// halt.
+		output, err := Run(program, a, 1000)
+		if err != nil {
+			continue
+		}
+
+		name := fmt.Sprintf("program%d", n)
+		generated, err := GoSource(program, "main", name)
+		if err != nil {
+			t.Fatalf("%s: %v", program, err)
+		}
+
+		// Each function is its own file's worth of source, so drop the
+		// header to put them all in one.
+		_, body, _ := strings.Cut(string(generated), "package main\n")
+		source.WriteString(body)
+		fmt.Fprintf(&calls, "\tfmt.Println(%s(%d, %d, %d))\n", name, a, program.Registers.B, program.Registers.C)
+		fmt.Fprintf(&want, "%v\n", output)
+		n++
+	}
+
+	source.WriteString("\nfunc main() {\n" + calls.String() + "}\n")
+
+	file := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(file, []byte(source.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := exec.Command("go", "run", file).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v\n%s", err, got)
+	}
+
+	gotLines := strings.Split(string(got), "\n")
+	for i, line := range strings.Split(want.String(), "\n") {
+		if i >= len(gotLines) || gotLines[i] != line {
+			t.Fatalf("program%d outputs %q, want %q", i, gotLines[i], line)
+		}
+	}
+}
+
+func TestGoSourceRejects(t *testing.T) {
+	for _, instructions := range [][]Instruction{
+		{{BST, 7}},
+		{{JNZ, 1}},
+		{{ADV, 3}, {JNZ, 5}},
+	} {
+		program := Program{Instructions: instructions}
+		if _, err := GoSource(program, "main", "run"); err == nil {
+			t.Errorf("%s compiles, but should not", program)
+		}
+	}
+}
+
+// benchmarkProgram is the usual shape of a real input, which outputs 16
+// values for a 48-bit A.
+var benchmarkProgram = Program{Instructions: []Instruction{
+	{BST, 4}, {BXL, 1}, {CDV, 5}, {BXL, 5}, {BXC, 0}, {ADV, 3}, {OUT, 5}, {JNZ, 0},
+}}
+
+func BenchmarkInterpreter(b *testing.B) {
+	for n := range b.N {
+		if _, err := Run(benchmarkProgram, 1<<45|uint64(n), 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiled(b *testing.B) {
+	compiled := Compile(benchmarkProgram)
+	for n := range b.N {
+		if _, err := compiled.Run(1<<45|uint64(n), 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
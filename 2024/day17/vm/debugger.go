@@ -0,0 +1,212 @@
+package vm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Snapshot is the state of a machine between steps. The output is only kept
+// as a length, as a machine never changes what it has already output.
+type Snapshot struct {
+	Registers
+	IP      int
+	Outputs int
+	Steps   int
+}
+
+func (m *Machine) Snapshot() Snapshot {
+	return Snapshot{Registers: m.Registers, IP: m.IP, Outputs: len(m.Output), Steps: m.Steps}
+}
+
+// Restore puts the machine back to an earlier snapshot of itself.
+func (m *Machine) Restore(s Snapshot) {
+	m.Registers = s.Registers
+	m.IP = s.IP
+	m.Output = m.Output[:s.Outputs]
+	m.Steps = s.Steps
+}
+
+// registerNames are what breakpoints and watches can refer to. The
+// instruction pointer is given as a position in the code, as jumps are.
+var registerNames = []string{"a", "b", "c", "ip", "out", "steps"}
+
+// Value returns a register by name.
+func (s Snapshot) Value(name string) (uint64, bool) {
+	switch strings.ToLower(name) {
+	case "a":
+		return s.A, true
+	case "b":
+		return s.B, true
+	case "c":
+		return s.C, true
+	case "ip":
+		return uint64(2 * s.IP), true
+	case "out":
+		return uint64(s.Outputs), true
+	case "steps":
+		return uint64(s.Steps), true
+	}
+	return 0, false
+}
+
+// Breakpoint stops a debugger before running an instruction, either at a
+// position in the code, or when a register compares to a value.
+type Breakpoint struct {
+	Text     string
+	register string
+	compare  func(a, b uint64) bool
+	value    uint64
+}
+
+var comparisons = []struct {
+	operator string
+	compare  func(a, b uint64) bool
+}{
+	// Longest first, so that <= is not read as <
+	{"==", func(a, b uint64) bool { return a == b }},
+	{"!=", func(a, b uint64) bool { return a != b }},
+	{"<=", func(a, b uint64) bool { return a <= b }},
+	{">=", func(a, b uint64) bool { return a >= b }},
+	{"<", func(a, b uint64) bool { return a < b }},
+	{">", func(a, b uint64) bool { return a > b }},
+}
+
+// ParseBreakpoint reads a breakpoint: either a position in the code, such as
+// `4`, or a register, comparison and value, such as `a==0` or `out>=3`.
+func ParseBreakpoint(text string) (Breakpoint, error) {
+	text = strings.ReplaceAll(text, " ", "")
+
+	if position, err := strconv.ParseUint(text, 0, 64); err == nil {
+		return Breakpoint{Text: "ip==" + text, register: "ip", compare: comparisons[0].compare, value: position}, nil
+	}
+
+	for _, c := range comparisons {
+		register, number, found := strings.Cut(text, c.operator)
+		if !found {
+			continue
+		}
+
+		if _, ok := (Snapshot{}).Value(register); !ok {
+			return Breakpoint{}, fmt.Errorf("unknown register %q, expected one of %s", register, strings.Join(registerNames, ", "))
+		}
+
+		value, err := strconv.ParseUint(number, 0, 64)
+		if err != nil {
+			return Breakpoint{}, fmt.Errorf("invalid value %q", number)
+		}
+
+		return Breakpoint{Text: text, register: strings.ToLower(register), compare: c.compare, value: value}, nil
+	}
+
+	return Breakpoint{}, fmt.Errorf("invalid breakpoint %q", text)
+}
+
+// Hit reports whether the breakpoint matches the state of the machine.
+func (b Breakpoint) Hit(s Snapshot) bool {
+	value, _ := s.Value(b.register)
+	return b.compare(value, b.value)
+}
+
+// Debugger runs a machine a step at a time, remembering enough of its
+// history to be able to step backwards.
+type Debugger struct {
+	Machine     *Machine
+	Breakpoints []Breakpoint
+
+	// Watches are registers to report on whenever they change.
+	Watches []string
+
+	// history is a ring buffer of the snapshots before each step.
+	history []Snapshot
+	next    int
+	count   int
+}
+
+// NewDebugger returns a debugger which can step back up to history steps.
+func NewDebugger(m *Machine, history int) *Debugger {
+	return &Debugger{Machine: m, history: make([]Snapshot, max(history, 1))}
+}
+
+// Watch adds a register to report changes in.
+func (d *Debugger) Watch(name string) error {
+	if _, ok := (Snapshot{}).Value(name); !ok {
+		return fmt.Errorf("unknown register %q, expected one of %s", name, strings.Join(registerNames, ", "))
+	}
+	d.Watches = append(d.Watches, strings.ToLower(name))
+	return nil
+}
+
+// Changes describes each watched register that is different now from in the
+// snapshot.
+func (d *Debugger) Changes(before Snapshot) []string {
+	after := d.Machine.Snapshot()
+	changes := make([]string, 0)
+
+	for _, name := range d.Watches {
+		old, _ := before.Value(name)
+		current, _ := after.Value(name)
+		if old != current {
+			changes = append(changes, fmt.Sprintf("%s: %d -> %d", name, old, current))
+		}
+	}
+
+	return changes
+}
+
+// Step runs one instruction, remembering the state before it.
+func (d *Debugger) Step() (TraceRecord, bool, error) {
+	if d.Machine.Halted() {
+		return TraceRecord{}, false, nil
+	}
+
+	snapshot := d.Machine.Snapshot()
+
+	record, running, err := d.Machine.TraceStep()
+	if err != nil {
+		return record, running, err
+	}
+
+	d.history[d.next] = snapshot
+	d.next = (d.next + 1) % len(d.history)
+	d.count = min(d.count+1, len(d.history))
+
+	return record, running, nil
+}
+
+// Back undoes the last step, returning false if there is no more history.
+func (d *Debugger) Back() bool {
+	if d.count == 0 {
+		return false
+	}
+
+	d.next = (d.next - 1 + len(d.history)) % len(d.history)
+	d.count--
+	d.Machine.Restore(d.history[d.next])
+
+	return true
+}
+
+// Continue steps until the machine halts, or is about to run an instruction
+// where a breakpoint is hit, which is returned. It always runs at least one
+// step, so that continuing from a breakpoint moves on. It gives up with
+// ErrStepLimit after limit steps, if limit is more than zero.
+func (d *Debugger) Continue(limit int) (*Breakpoint, error) {
+	for steps := 0; ; steps++ {
+		if limit > 0 && steps >= limit {
+			return nil, fmt.Errorf("%w after %d steps", ErrStepLimit, steps)
+		}
+
+		_, running, err := d.Step()
+		if err != nil || !running || d.Machine.Halted() {
+			return nil, err
+		}
+
+		snapshot := d.Machine.Snapshot()
+		for i := range d.Breakpoints {
+			if d.Breakpoints[i].Hit(snapshot) {
+				return &d.Breakpoints[i], nil
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestDebuggerBack(t *testing.T) {
+	program, err := Parse([]byte("Register A: 729\nRegister B: 0\nRegister C: 0\n\nProgram: 0,1,5,4,3,0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDebugger(New(program), 5)
+	start := d.Machine.Snapshot()
+
+	for range 8 {
+		if _, _, err := d.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	after := d.Machine.Snapshot()
+
+	// Only the last five steps can be undone.
+	for range 5 {
+		if !d.Back() {
+			t.Fatal("ran out of history too soon")
+		}
+	}
+	if d.Back() {
+		t.Error("stepped back further than the history")
+	}
+	if got, want := d.Machine.Snapshot().Steps, start.Steps+3; got != want {
+		t.Errorf("back at step %d, want %d", got, want)
+	}
+
+	for range 5 {
+		if _, _, err := d.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := d.Machine.Snapshot(); got != after {
+		t.Errorf("stepping forward again gives %+v, want %+v", got, after)
+	}
+}
+
+func TestDebuggerBreakpoints(t *testing.T) {
+	program, err := Parse([]byte("Register A: 729\nRegister B: 0\nRegister C: 0\n\nProgram: 0,1,5,4,3,0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		breakpoint string
+		want       func(Snapshot) bool
+	}{
+		{"4", func(s Snapshot) bool { return s.IP == 2 && s.Steps == 2 }},
+		{"out >= 3", func(s Snapshot) bool { return s.Outputs == 3 }},
+		{"a<100", func(s Snapshot) bool { return s.A == 91 }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.breakpoint, func(t *testing.T) {
+			breakpoint, err := ParseBreakpoint(test.breakpoint)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := NewDebugger(New(program), 10)
+			d.Breakpoints = append(d.Breakpoints, breakpoint)
+
+			hit, err := d.Continue(1000)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hit == nil || !test.want(d.Machine.Snapshot()) {
+				t.Errorf("stopped at %+v (hit %v)", d.Machine.Snapshot(), hit)
+			}
+		})
+	}
+}
+
+// FuzzParseBreakpoint checks that a breakpoint's text parses back as the
+// same breakpoint.
+func FuzzParseBreakpoint(f *testing.F) {
+	for _, text := range []string{"4", "0x10", "a==0", "out >= 3", "B!=7", "steps<100", "ip>2", "c<=0b11", "a=<1", "d==1", ""} {
+		f.Add(text)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		breakpoint, err := ParseBreakpoint(text)
+		if err != nil {
+			return
+		}
+
+		again, err := ParseBreakpoint(breakpoint.Text)
+		if err != nil {
+			t.Fatalf("%q gives %q, which does not parse: %v", text, breakpoint.Text, err)
+		}
+		if again.Text != breakpoint.Text || again.register != breakpoint.register || again.value != breakpoint.value {
+			t.Fatalf("%q gives %+v, which parses as %+v", text, breakpoint, again)
+		}
+
+		for _, value := range []uint64{0, breakpoint.value - 1, breakpoint.value, breakpoint.value + 1} {
+			s := Snapshot{Registers: Registers{A: value, B: value, C: value}, IP: int(value / 2), Outputs: int(value), Steps: int(value)}
+			if breakpoint.Hit(s) != again.Hit(s) {
+				t.Fatalf("%q and %q disagree at %d", text, breakpoint.Text, value)
+			}
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package vm
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var comboNames = [8]string{"0", "1", "2", "3", "A", "B", "C", "?"}
+
+// Explain describes what the instruction does in terms of the registers.
+func (i Instruction) Explain() string {
+	operand := comboNames[i.Operand&7]
+
+	switch i.Opcode {
+	case ADV:
+		return "A = A / 2^" + operand
+	case BXL:
+		return "B = B xor " + strconv.Itoa(int(i.Operand))
+	case BST:
+		return "B = (" + operand + " & 7)"
+	case JNZ:
+		return fmt.Sprintf("JUMP %02d", i.Operand)
+	case BXC:
+		return "B = B xor C"
+	case OUT:
+		return "OUTPUT " + operand + " & 7"
+	case BDV:
+		return "B = A / 2^" + operand
+	case CDV:
+		return "C = A / 2^" + operand
+	}
+	return fmt.Sprintf("unknown instruction %d,%d", i.Opcode, i.Operand)
+}
+
+// Disassemble writes out each instruction of the program with its position
+// in the code, which is what jumps refer to.
+func Disassemble(w io.Writer, program Program) error {
+	for i, inst := range program.Instructions {
+		if _, err := fmt.Fprintf(w, "%02d  %s\n", 2*i, inst.Explain()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// GoSource writes the program as a Go function in package pkg,
+//
+//	func name(a, b, c uint64) []uint8
+//
+// which returns what the program outputs starting from those registers.
+// Jumps become gotos, so the function is straight-line code the Go compiler
+// can optimise as a whole, for use in searches too slow for the interpreter.
+// There is no step limit, so a program which never halts gives a function
+// which never returns. Programs with invalid instructions or odd jumps are
+// rejected, as the function has no way to report them.
+func GoSource(program Program, pkg, name string) ([]byte, error) {
+	targets := make(map[int]bool)
+	for index, i := range program.Instructions {
+		if err := i.Valid(); err != nil {
+			return nil, fmt.Errorf("at %d: %w", 2*index, err)
+		}
+		if i.Opcode == JNZ {
+			if i.Operand%2 != 0 {
+				return nil, fmt.Errorf("at %d: %w %d", 2*index, ErrOddJump, i.Operand)
+			}
+			targets[int(i.Operand/2)] = true
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by aoc vm compile; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "// %s runs the program %s.\n", name, program)
+	fmt.Fprintf(&buf, "func %s(a, b, c uint64) []uint8 {\n", name)
+	fmt.Fprintf(&buf, "out := make([]uint8, 0, 16)\n")
+
+	operand := func(i Instruction) string {
+		if i.Opcode.UsesCombo() {
+			return strings.ToLower(comboNames[i.Operand])
+		}
+		return fmt.Sprint(i.Operand)
+	}
+
+	for index, i := range program.Instructions {
+		if targets[index] {
+			fmt.Fprintf(&buf, "l%d:\n", 2*index)
+		}
+		fmt.Fprintf(&buf, "// %02d  %s\n", 2*index, i)
+
+		switch i.Opcode {
+		case ADV:
+			fmt.Fprintf(&buf, "a >>= %s\n", operand(i))
+		case BXL:
+			fmt.Fprintf(&buf, "b ^= %d\n", i.Operand)
+		case BST:
+			fmt.Fprintf(&buf, "b = %s & 7\n", operand(i))
+		case JNZ:
+			fmt.Fprintf(&buf, "if a != 0 {\n")
+			if target := int(i.Operand / 2); target < len(program.Instructions) {
+				fmt.Fprintf(&buf, "goto l%d\n", i.Operand)
+			} else {
+				fmt.Fprintf(&buf, "return out\n")
+			}
+			fmt.Fprintf(&buf, "}\n")
+		case BXC:
+			fmt.Fprintf(&buf, "b ^= c\n")
+		case OUT:
+			fmt.Fprintf(&buf, "out = append(out, uint8(%s&7))\n", operand(i))
+		case BDV:
+			fmt.Fprintf(&buf, "b = a >> %s\n", operand(i))
+		case CDV:
+			fmt.Fprintf(&buf, "c = a >> %s\n", operand(i))
+		}
+	}
+
+	fmt.Fprintf(&buf, "return out\n}\n")
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,73 @@
+package vm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Parse reads the registers and program, checking that the program is pairs
+// of 3-bit numbers that only use valid combo operands.
+func Parse(raw []byte) (Program, error) {
+	return Load(bytes.NewReader(raw))
+}
+
+// Load is Parse for a reader.
+func Load(reader io.Reader) (Program, error) {
+	program := Program{}
+	var byteCode string
+
+	if _, err := fmt.Fscanf(reader, "Register A: %d\n", &program.Registers.A); err != nil {
+		return Program{}, fmt.Errorf("register A: %w", err)
+	}
+	if _, err := fmt.Fscanf(reader, "Register B: %d\n", &program.Registers.B); err != nil {
+		return Program{}, fmt.Errorf("register B: %w", err)
+	}
+	if _, err := fmt.Fscanf(reader, "Register C: %d\n", &program.Registers.C); err != nil {
+		return Program{}, fmt.Errorf("register C: %w", err)
+	}
+	_, _ = fmt.Fscanf(reader, "\n")
+	if _, err := fmt.Fscanf(reader, "Program: %s\n", &byteCode); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return Program{}, fmt.Errorf("program: %w", err)
+	}
+
+	instructions, err := ParseCode(byteCode)
+	if err != nil {
+		return Program{}, err
+	}
+	program.Instructions = instructions
+
+	return program, nil
+}
+
+// ParseCode reads the instructions from the `o,o,...` form of a program.
+func ParseCode(byteCode string) ([]Instruction, error) {
+	// Every value is one digit, so the program is `o,o,...,o`.
+	if len(byteCode)%4 != 3 {
+		return nil, fmt.Errorf("program %q is not pairs of opcodes and operands", byteCode)
+	}
+
+	for i := 0; i < len(byteCode); i += 2 {
+		if byteCode[i] < '0' || byteCode[i] > '7' {
+			return nil, fmt.Errorf("program %q: invalid value %q at %d", byteCode, byteCode[i], i)
+		}
+		if i+1 < len(byteCode) && byteCode[i+1] != ',' {
+			return nil, fmt.Errorf("program %q: expected ',' at %d", byteCode, i+1)
+		}
+	}
+
+	instructions := make([]Instruction, 0, len(byteCode)/4+1)
+
+	for i := 0; i < len(byteCode)-1; i += 4 {
+		step := Instruction{Opcode: Opcode(byteCode[i] - '0'), Operand: byteCode[i+2] - '0'}
+
+		if err := step.Valid(); err != nil {
+			return nil, fmt.Errorf("program %q: instruction %d: %w", byteCode, i/4, err)
+		}
+
+		instructions = append(instructions, step)
+	}
+
+	return instructions, nil
+}
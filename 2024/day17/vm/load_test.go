@@ -0,0 +1,40 @@
+package vm
+
+import (
+	"errors"
+	"testing"
+)
+
+// FuzzParse checks that the loader either rejects its input or returns a
+// program which can run without panicking, and which prints back the same.
+func FuzzParse(f *testing.F) {
+	f.Add([]byte("Register A: 729\nRegister B: 0\nRegister C: 0\n\nProgram: 0,1,5,4,3,0\n"))
+	f.Add([]byte("Register A: 2024\nRegister B: 0\nRegister C: 0\n\nProgram: 0,3,5,4,3,0\n"))
+	f.Add([]byte("Register A: 117440\nRegister B: 0\nRegister C: 0\n\nProgram: 0,3,5,4,3,0"))
+
+	// Regressions: a program cut off mid-instruction, and shifting A by more
+	// than 63 (which divided by zero).
+	f.Add([]byte("Register A: 1\nRegister B: 0\nRegister C: 0\n\nProgram: 0,1,5\n"))
+	f.Add([]byte("Register A: 64\nRegister B: 0\nRegister C: 0\n\nProgram: 0,4,5,4\n"))
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		program, err := Parse(input)
+		if err != nil {
+			return
+		}
+
+		reparsed, err := ParseCode(program.String())
+		if err != nil {
+			t.Fatalf("%q does not parse again: %v", program.String(), err)
+		}
+		if len(reparsed) != len(program.Instructions) {
+			t.Fatalf("%q parses as %d instructions, not %d", program.String(), len(reparsed), len(program.Instructions))
+		}
+
+		_, err = New(program).Run(100)
+		// Only odd jumps are caught at run time.
+		if err != nil && !errors.Is(err, ErrStepLimit) && !errors.Is(err, ErrOddJump) {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrStepLimit = errors.New("step limit reached")
+	ErrOddJump   = errors.New("jump to odd position")
+)
+
+// Machine is a program being run.
+type Machine struct {
+	Registers
+	Instructions []Instruction
+
+	// IP is the index of the next instruction, rather than the position in
+	// the code, so a jump to literal n sets it to n/2.
+	IP     int
+	Output []uint8
+	Steps  int
+}
+
+// New returns a machine ready to run the program from the beginning.
+func New(program Program) *Machine {
+	return &Machine{
+		Registers:    program.Registers,
+		Instructions: program.Instructions,
+	}
+}
+
+// Halted reports whether the instruction pointer has left the program.
+func (m *Machine) Halted() bool {
+	return m.IP < 0 || m.IP >= len(m.Instructions)
+}
+
+func (m *Machine) combo(operand uint8) uint64 {
+	switch operand {
+	case 4:
+		return m.A
+	case 5:
+		return m.B
+	case 6:
+		return m.C
+	}
+	return uint64(operand)
+}
+
+// shift is the division instructions' A / 2^operand. Shifting by 64 or more
+// gives zero, where a division would have divided by zero.
+func (m *Machine) shift(operand uint8) uint64 {
+	return m.A >> m.combo(operand)
+}
+
+// Step runs one instruction, returning false once the machine has halted.
+func (m *Machine) Step() (bool, error) {
+	if m.Halted() {
+		return false, nil
+	}
+
+	step := m.Instructions[m.IP]
+	if err := step.Valid(); err != nil {
+		return false, fmt.Errorf("at %d: %w", m.IP, err)
+	}
+
+	m.Steps++
+
+	switch step.Opcode {
+	case ADV:
+		m.A = m.shift(step.Operand)
+	case BXL:
+		m.B ^= uint64(step.Operand)
+	case BST:
+		m.B = m.combo(step.Operand) & 7
+	case JNZ:
+		if m.A != 0 {
+			// Jumps to odd positions would read the operands as opcodes,
+			// which this representation cannot do.
+			if step.Operand%2 != 0 {
+				return false, fmt.Errorf("at %d: %w %d", m.IP, ErrOddJump, step.Operand)
+			}
+			m.IP = int(step.Operand / 2)
+			return true, nil
+		}
+	case BXC:
+		m.B ^= m.C
+	case OUT:
+		m.Output = append(m.Output, uint8(m.combo(step.Operand)&7))
+	case BDV:
+		m.B = m.shift(step.Operand)
+	case CDV:
+		m.C = m.shift(step.Operand)
+	}
+
+	m.IP++
+	return true, nil
+}
+
+// Run steps the machine until it halts, or until it has run limit
+// instructions in total, in which case it returns ErrStepLimit. A limit of
+// zero or less means no limit.
+func (m *Machine) Run(limit int) ([]uint8, error) {
+	for {
+		if limit > 0 && m.Steps >= limit && !m.Halted() {
+			return m.Output, fmt.Errorf("%w after %d steps", ErrStepLimit, m.Steps)
+		}
+
+		running, err := m.Step()
+		if err != nil {
+			return m.Output, err
+		}
+		if !running {
+			return m.Output, nil
+		}
+	}
+}
+
+// Run runs the program with A set to a, and the other registers as given in
+// the program, returning the output.
+func Run(program Program, a uint64, limit int) ([]uint8, error) {
+	m := New(program)
+	m.A = a
+	return m.Run(limit)
+}
@@ -0,0 +1,77 @@
+package vm
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// TraceRecord describes one step of a run, with the registers as they are
+// after it. Traces of two runs can be lined up by Step to see where they
+// differ.
+type TraceRecord struct {
+	Step    int    `json:"step"`
+	IP      int    `json:"ip"`
+	Op      string `json:"op"`
+	Operand uint8  `json:"operand"`
+	A       uint64 `json:"a"`
+	B       uint64 `json:"b"`
+	C       uint64 `json:"c"`
+	Out     *uint8 `json:"out,omitempty"`
+	Jumped  bool   `json:"jumped,omitempty"`
+}
+
+// TraceStep is Step, also returning a record of what the step did. The
+// record is only meaningful if the machine was still running.
+func (m *Machine) TraceStep() (TraceRecord, bool, error) {
+	if m.Halted() {
+		return TraceRecord{}, false, nil
+	}
+
+	ip := m.IP
+	inst := m.Instructions[ip]
+	outputs := len(m.Output)
+
+	running, err := m.Step()
+	if err != nil {
+		return TraceRecord{}, false, err
+	}
+
+	record := TraceRecord{
+		Step:    m.Steps,
+		IP:      2 * ip,
+		Op:      inst.Opcode.String(),
+		Operand: inst.Operand,
+		A:       m.A,
+		B:       m.B,
+		C:       m.C,
+		Jumped:  inst.Opcode == JNZ && m.IP != ip+1,
+	}
+	if len(m.Output) > outputs {
+		out := m.Output[outputs]
+		record.Out = &out
+	}
+
+	return record, running, nil
+}
+
+// Trace runs the machine, writing a line of JSON for each step. It stops
+// with ErrStepLimit after limit steps, if limit is more than zero.
+func Trace(w io.Writer, m *Machine, limit int) error {
+	encoder := json.NewEncoder(w)
+
+	for !m.Halted() {
+		if limit > 0 && m.Steps >= limit {
+			return ErrStepLimit
+		}
+
+		record, _, err := m.TraceStep()
+		if err != nil {
+			return err
+		}
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Package vm is the 3-bit computer from day 17: the program representation,
+// a loader which checks programs are valid, an interpreter, and a
+// disassembler for working out what a program does.
+package vm
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Opcode uint8
+
+const (
+	ADV Opcode = iota // A = A >> combo
+	BXL               // B = B xor literal
+	BST               // B = combo & 7
+	JNZ               // if A != 0, jump to literal
+	BXC               // B = B xor C (the operand is ignored)
+	OUT               // output combo & 7
+	BDV               // B = A >> combo
+	CDV               // C = A >> combo
+)
+
+var mnemonics = [8]string{"adv", "bxl", "bst", "jnz", "bxc", "out", "bdv", "cdv"}
+
+func (o Opcode) String() string {
+	if o > CDV {
+		return fmt.Sprintf("Opcode(%d)", uint8(o))
+	}
+	return mnemonics[o]
+}
+
+// UsesCombo reports whether the operand of the opcode is a combo operand,
+// which can refer to a register, rather than a literal.
+func (o Opcode) UsesCombo() bool {
+	return o != BXL && o != JNZ && o != BXC
+}
+
+// Instruction is an opcode and its operand, each one 3-bit number.
+type Instruction struct {
+	Opcode  Opcode
+	Operand uint8
+}
+
+// Valid checks that the instruction can be run: both parts are three bits,
+// and combo operand 7, which is reserved, is not used.
+func (i Instruction) Valid() error {
+	if i.Opcode > CDV || i.Operand > 7 {
+		return fmt.Errorf("instruction %d,%d is not two 3-bit numbers", i.Opcode, i.Operand)
+	}
+	if i.Operand == 7 && i.Opcode.UsesCombo() {
+		return fmt.Errorf("%s uses reserved combo operand 7", i.Opcode)
+	}
+	return nil
+}
+
+type Registers struct {
+	A, B, C uint64
+}
+
+// Program is the initial state of the computer and the instructions it runs.
+type Program struct {
+	Registers    Registers
+	Instructions []Instruction
+}
+
+// Code is the program as the list of 3-bit numbers it is written as, which
+// is also what a program that outputs itself has to output.
+func (p Program) Code() []uint8 {
+	code := make([]uint8, 0, 2*len(p.Instructions))
+	for _, i := range p.Instructions {
+		code = append(code, uint8(i.Opcode), i.Operand)
+	}
+	return code
+}
+
+// String is the program as it appears on the Program line of the input.
+func (p Program) String() string {
+	return FormatOutput(p.Code())
+}
+
+// FormatOutput joins output values with commas, which is how both the
+// program and the answer to part 1 are written.
+func FormatOutput(values []uint8) string {
+	var builder strings.Builder
+	for i, value := range values {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteByte('0' + value)
+	}
+	return builder.String()
+}
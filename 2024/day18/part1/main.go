@@ -7,7 +7,6 @@ import (
 	"io"
 	"log"
 	"math"
-	"os"
 	"sort"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
@@ -235,7 +234,7 @@ func main() {
 
 func loadData(steps int) dijkstraGrid {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-18.txt")
+	dataFile, err := utils.OpenInput(18)
 
 	if err != nil {
 		panic(err)
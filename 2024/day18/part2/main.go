@@ -6,7 +6,6 @@ import (
 	"image"
 	"io"
 	"math"
-	"os"
 	"sort"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
@@ -250,7 +249,7 @@ func main() {
 
 func loadData(size int) dijkstraGrid {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-18.txt")
+	dataFile, err := utils.OpenInput(18)
 
 	if err != nil {
 		panic(err)
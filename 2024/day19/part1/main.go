@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"strings"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
@@ -49,7 +48,7 @@ func hasOptions(request string, availableTowels *[]string) bool {
 
 func loadData() ([]string, []string) {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-19.txt")
+	dataFile, err := utils.OpenInput(19)
 
 	if err != nil {
 		panic(err)
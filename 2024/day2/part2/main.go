@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"os"
 	"strconv"
 	"strings"
 	"tea-cats.co.uk/aoc/2024"
@@ -20,7 +19,7 @@ func main() {
 
 func loadData() int {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-2.txt")
+	dataFile, err := utils.OpenInput(2)
 
 	if err != nil {
 		panic(err)
@@ -6,7 +6,6 @@ import (
 	"image"
 	"log"
 	"math"
-	"os"
 	"slices"
 	"sort"
 	"tea-cats.co.uk/aoc/2024"
@@ -294,7 +293,7 @@ func generatePossibleCheats(radius int) []cheat {
 
 func loadData() dijkstraGrid {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-20.txt")
+	dataFile, err := utils.OpenInput(20)
 
 	if err != nil {
 		panic(err)
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
 )
@@ -42,7 +41,7 @@ func processSecret(secret uint64, rounds int) uint64 {
 
 func loadData() []uint64 {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-22.txt")
+	dataFile, err := utils.OpenInput(22)
 
 	if err != nil {
 		panic(err)
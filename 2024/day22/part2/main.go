@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"sort"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
@@ -96,7 +95,7 @@ func mapSecretDelta(secret uint64, rounds int) map[uint32]int {
 
 func loadData() []uint64 {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-22.txt")
+	dataFile, err := utils.OpenInput(22)
 
 	if err != nil {
 		panic(err)
@@ -0,0 +1,45 @@
+package day23
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	utils "tea-cats.co.uk/aoc/2024"
+	"tea-cats.co.uk/aoc/2024/graph"
+	"time"
+)
+
+const debug = false
+
+type Input struct {
+	Network *graph.Graph
+}
+
+// LoadData reads the links, one `a-b` per line. Names can be any length.
+func LoadData(raw []byte) (Input, error) {
+	defer utils.TimeTrack(time.Now(), "loadData")
+
+	network := graph.New()
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		a, b, ok := strings.Cut(text, "-")
+		if !ok || a == "" || b == "" || strings.Contains(b, "-") {
+			return Input{}, fmt.Errorf("line %d: expected `a-b`, got %q", line, text)
+		}
+		network.LinkNames(a, b)
+	}
+	if err := scanner.Err(); err != nil {
+		return Input{}, err
+	}
+
+	return Input{Network: network}, nil
+}
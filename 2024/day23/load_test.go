@@ -0,0 +1,81 @@
+package day23
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// FuzzLoadData checks that every link the loader accepts is in the network
+// both ways round, and that the password part 2 gives for small networks
+// names computers which are all linked to each other.
+func FuzzLoadData(f *testing.F) {
+	log.SetOutput(io.Discard)
+
+	raw, err := os.ReadFile("testdata/example-1.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(raw)
+	f.Add([]byte("ta-tb\n"))
+	f.Add([]byte("a-a\n"))
+	f.Add([]byte("a-b-c\n"))
+	f.Add([]byte("  x-y  \n\n"))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		input, err := LoadData(raw)
+		if err != nil {
+			return
+		}
+
+		network := input.Network
+		for _, line := range strings.Split(string(raw), "\n") {
+			a, b, ok := strings.Cut(strings.TrimSpace(line), "-")
+			if !ok {
+				continue
+			}
+			first, ok := network.Lookup(a)
+			if !ok {
+				t.Fatalf("%q is not in the network", a)
+			}
+			second, ok := network.Lookup(b)
+			if !ok {
+				t.Fatalf("%q is not in the network", b)
+			}
+			if a != b && (!network.Linked(first, second) || !network.Linked(second, first)) {
+				t.Fatalf("%s and %s are not linked both ways", a, b)
+			}
+		}
+		// The password could not be split up again if the names had commas.
+		if len(raw) > 200 || bytes.ContainsRune(raw, ',') {
+			return
+		}
+
+		ctx := context.Background()
+		if _, err := Part1(ctx, input); err != nil {
+			t.Fatal(err)
+		}
+		password, err := Part2(ctx, input)
+		if err != nil {
+			return
+		}
+		names := strings.Split(password.(string), ",")
+		if !slices.IsSorted(names) {
+			t.Fatalf("password %s is not sorted", password)
+		}
+		for i, a := range names {
+			for _, b := range names[i+1:] {
+				first, _ := network.Lookup(a)
+				second, _ := network.Lookup(b)
+				if !network.Linked(first, second) {
+					t.Fatalf("%s and %s in password %s are not linked", a, b, password)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package day23
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Part1 counts the sets of three computers which are all linked to each
+// other, where at least one has a name starting with t.
+func Part1(_ context.Context, input Input) (any, error) {
+	network := input.Network
+
+	return network.CountCliques(3, func(clique []int) bool {
+		names := network.Names(clique)
+		if debug {
+			fmt.Println(strings.Join(names, ","))
+		}
+		for _, name := range names {
+			if strings.HasPrefix(name, "t") {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
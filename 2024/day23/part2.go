@@ -0,0 +1,22 @@
+package day23
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// Part2 is the password for the LAN party: the names of the largest set of
+// computers which are all linked to each other, sorted and joined with
+// commas.
+func Part2(ctx context.Context, input Input) (any, error) {
+	party, err := input.Network.MaximumClique(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := input.Network.Names(party)
+	slices.Sort(names)
+
+	return strings.Join(names, ","), nil
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	_ "tea-cats.co.uk/aoc/2024/day23"
+	"tea-cats.co.uk/aoc/runner"
+)
+
+func main() {
+	runner.Main(2024, 23, 2)
+}
@@ -0,0 +1,48 @@
+package day23
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"tea-cats.co.uk/aoc/2024/generate"
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/runner/runnertest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	runnertest.Examples(t, []runnertest.Example{
+		{Name: "part1", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part1), Want: "7"},
+		{Name: "part2", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part2), Want: "co,de,ka,ta"},
+	})
+}
+
+// TestPartyGenerated checks that the password for generated networks is the
+// party of thirteen they are built around, all linked to each other.
+func TestPartyGenerated(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		input, err := LoadData(generate.Day23(generate.NewRand(seed), 520))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Part2(context.Background(), input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		names := strings.Split(got.(string), ",")
+		if len(names) < 13 || !slices.IsSorted(names) {
+			t.Errorf("seed %d: got %s", seed, got)
+		}
+		for i, a := range names {
+			for _, b := range names[i+1:] {
+				first, _ := input.Network.Lookup(a)
+				second, _ := input.Network.Lookup(b)
+				if !input.Network.Linked(first, second) {
+					t.Errorf("seed %d: %s and %s are not linked", seed, a, b)
+				}
+			}
+		}
+	}
+}
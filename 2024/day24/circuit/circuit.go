@@ -0,0 +1,116 @@
+// Package circuit is the network of logic gates from day 24: a parser for
+// the netlist, which sorts the gates so each one comes after the gates
+// driving its inputs, and a simulator which evaluates them in that order
+// over wires numbered rather than named.
+package circuit
+
+import (
+	"fmt"
+)
+
+type Op uint8
+
+const (
+	AND Op = iota
+	OR
+	XOR
+)
+
+var opNames = [3]string{"AND", "OR", "XOR"}
+
+func (o Op) String() string {
+	if o > XOR {
+		return fmt.Sprintf("Op(%d)", uint8(o))
+	}
+	return opNames[o]
+}
+
+// Apply is the gate's output for the given inputs.
+func (o Op) Apply(left, right bool) bool {
+	switch o {
+	case AND:
+		return left && right
+	case OR:
+		return left || right
+	}
+	return left != right
+}
+
+// Gate reads two wires and drives a third, all given by their index in the
+// circuit.
+type Gate struct {
+	Op          Op
+	Left, Right int
+	Output      int
+}
+
+// Circuit is a parsed netlist. Gates are in an order where every gate comes
+// after the ones driving its inputs, so evaluating them in turn leaves every
+// wire with its final value.
+type Circuit struct {
+	// names is the name of each wire, and index the reverse.
+	names []string
+	index map[string]int
+
+	// driver is the index in Gates of the gate driving each wire, or -1
+	// for inputs.
+	driver []int
+
+	Gates []Gate
+
+	// initial is the value each input wire was given in the netlist;
+	// values is the current value of every wire.
+	initial []bool
+	values  []bool
+}
+
+// Wires is how many wires there are, which are numbered from zero.
+func (c *Circuit) Wires() int {
+	return len(c.names)
+}
+
+// Name is the name of the numbered wire.
+func (c *Circuit) Name(wire int) string {
+	return c.names[wire]
+}
+
+// Wire looks up a wire by name.
+func (c *Circuit) Wire(name string) (int, bool) {
+	wire, ok := c.index[name]
+	return wire, ok
+}
+
+// Driver returns the gate driving the wire, and false for inputs.
+func (c *Circuit) Driver(wire int) (Gate, bool) {
+	if c.driver[wire] < 0 {
+		return Gate{}, false
+	}
+	return c.Gates[c.driver[wire]], true
+}
+
+// Inputs are the wires which no gate drives, in order of their names.
+func (c *Circuit) Inputs() []int {
+	inputs := make([]int, 0)
+	for wire, gate := range c.driver {
+		if gate < 0 {
+			inputs = append(inputs, wire)
+		}
+	}
+	return c.sortByName(inputs)
+}
+
+// Readers returns the gates which read the wire.
+func (c *Circuit) Readers(wire int) []Gate {
+	readers := make([]Gate, 0, 2)
+	for _, g := range c.Gates {
+		if g.Left == wire || g.Right == wire {
+			readers = append(readers, g)
+		}
+	}
+	return readers
+}
+
+// Format writes a gate out the way the netlist does.
+func (c *Circuit) Format(g Gate) string {
+	return fmt.Sprintf("%s %s %s -> %s", c.names[g.Left], g.Op, c.names[g.Right], c.names[g.Output])
+}
@@ -0,0 +1,105 @@
+package circuit
+
+import (
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"tea-cats.co.uk/aoc/2024/generate"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []struct {
+		file string
+		want uint64
+	}{
+		{"../testdata/example-1.txt", 4},
+		{"../testdata/example-2.txt", 2024},
+	}
+
+	for _, test := range tests {
+		raw, err := os.ReadFile(test.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, err := c.ReadBusUint64("z"); err != nil || got != test.want {
+			t.Errorf("%s: got %d (%v), want %d", test.file, got, err, test.want)
+		}
+	}
+}
+
+// TestAdder re-evaluates generated adders, some wider than 64 bits, for
+// random inputs.
+func TestAdder(t *testing.T) {
+	rng := generate.NewRand(47)
+
+	for _, bits := range []int{2, 5, 45, 64, 99} {
+		c, err := Parse(generate.Adder(rng, bits, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for range 20 {
+			x, y := randomBits(rng, bits), randomBits(rng, bits)
+
+			if err := c.SetBus("x", x); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.SetBus("y", y); err != nil {
+				t.Fatal(err)
+			}
+			c.Evaluate()
+
+			z, err := c.ReadBus("z")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := new(big.Int).Add(x, y); z.Cmp(want) != 0 {
+				t.Errorf("%d bits: %d + %d gives %d, want %d", bits, x, y, z, want)
+			}
+		}
+	}
+}
+
+func randomBits(rng *rand.Rand, bits int) *big.Int {
+	value := new(big.Int)
+	for i := range bits {
+		value.SetBit(value, i, uint(rng.IntN(2)))
+	}
+	return value
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		netlist string
+		want    string
+	}{
+		{"loop", "x: 1\n\nx AND b -> a\na OR c -> b\nb XOR x -> c\n", "b -> a -> b"},
+		{"undriven", "x: 1\n\nx AND y -> z\n", "wire y is read, but has no gate or value"},
+		{"driven twice", "x: 1\n\nx AND x -> z\nx OR x -> z\n", "wire z is driven by both"},
+		{"driven and given", "x: 1\nz: 0\n\nx AND x -> z\n", "but is also given a value"},
+		{"bad gate", "x: 1\n\nx NAND x -> z\n", `line 3: unknown gate "NAND"`},
+		{"bad value", "x: 2\n", "line 1: expected"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.netlist))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got %v, want an error containing %q", err, test.want)
+			}
+			if test.name == "loop" && !errors.Is(err, ErrCycle) {
+				t.Errorf("got %v, want %v", err, ErrCycle)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package circuit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// bits works out which bit each wire belongs to, for grouping the gates when
+// drawing them. A wire named with a number, such as x07, is that bit; a gate
+// is the highest bit of anything feeding into it, so in an adder each bit's
+// gates and its carry out end up together. Wires with no number anywhere
+// before them are -1.
+func (c *Circuit) bits() []int {
+	bits := make([]int, len(c.names))
+	for wire, name := range c.names {
+		bits[wire] = -1
+		if c.driver[wire] >= 0 {
+			continue
+		}
+		if _, number := splitNumber(name); number >= 0 {
+			bits[wire] = number
+		}
+	}
+
+	// The gates are sorted, so their inputs are done before them.
+	for _, g := range c.Gates {
+		bits[g.Output] = max(bits[g.Left], bits[g.Right])
+	}
+
+	return bits
+}
+
+// splitNumber splits a name like z07 into its prefix and number, returning
+// -1 for names which do not end in one.
+func splitNumber(name string) (string, int) {
+	prefix := strings.TrimRight(name, "0123456789")
+	if prefix == name {
+		return name, -1
+	}
+	number, err := strconv.Atoi(name[len(prefix):])
+	if err != nil {
+		return name, -1
+	}
+	return prefix, number
+}
+
+// WriteDOT draws the circuit for Graphviz, with a box for each gate labelled
+// with its operation and output, grouped by bit. The gates driving any of
+// the highlight wires are filled in red.
+func (c *Circuit) WriteDOT(w io.Writer, highlight []int) error {
+	out := bufio.NewWriter(w)
+	bits := c.bits()
+
+	fmt.Fprintln(out, "digraph circuit {")
+	fmt.Fprintln(out, "\trankdir=LR;")
+	fmt.Fprintln(out, "\tnode [shape=box, fontname=monospace];")
+
+	clusters := make(map[int][]int)
+	for wire := range c.names {
+		clusters[bits[wire]] = append(clusters[bits[wire]], wire)
+	}
+
+	node := func(wire int) {
+		name := strconv.Quote(c.names[wire])
+		g, driven := c.Driver(wire)
+		switch {
+		case !driven:
+			fmt.Fprintf(out, "\t\t%s [shape=ellipse];\n", name)
+		case slices.Contains(highlight, wire):
+			fmt.Fprintf(out, "\t\t%s [label=\"%s\\n%s\", style=filled, fillcolor=salmon, color=red];\n", name, g.Op, c.names[wire])
+		case len(c.Readers(wire)) == 0:
+			fmt.Fprintf(out, "\t\t%s [label=\"%s\\n%s\", peripheries=2];\n", name, g.Op, c.names[wire])
+		default:
+			fmt.Fprintf(out, "\t\t%s [label=\"%s\\n%s\"];\n", name, g.Op, c.names[wire])
+		}
+	}
+
+	for _, bit := range slices.Sorted(maps.Keys(clusters)) {
+		if bit < 0 {
+			for _, wire := range clusters[bit] {
+				node(wire)
+			}
+			continue
+		}
+
+		fmt.Fprintf(out, "\tsubgraph cluster_%d {\n", bit)
+		fmt.Fprintf(out, "\t\tlabel=\"bit %d\";\n", bit)
+		for _, wire := range clusters[bit] {
+			node(wire)
+		}
+		fmt.Fprintln(out, "\t}")
+	}
+
+	for _, g := range c.Gates {
+		fmt.Fprintf(out, "\t%q -> %q;\n", c.names[g.Left], c.names[g.Output])
+		fmt.Fprintf(out, "\t%q -> %q;\n", c.names[g.Right], c.names[g.Output])
+	}
+
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
+
+// verilogKeywords are the reserved words short enough to turn up as wire
+// names, which need escaping.
+var verilogKeywords = map[string]bool{
+	"and": true, "buf": true, "end": true, "for": true, "if": true, "nor": true,
+	"not": true, "or": true, "reg": true, "tri": true, "use": true, "wor": true,
+	"xor": true, "case": true, "cell": true, "else": true, "fork": true,
+	"join": true, "nand": true, "pmos": true, "nmos": true, "wait": true,
+	"wire": true, "wand": true, "xnor": true, "begin": true, "event": true,
+	"force": true, "input": true, "inout": true, "output": true, "module": true,
+	"assign": true, "always": true, "initial": true, "integer": true,
+	"supply0": true, "supply1": true, "tri0": true, "tri1": true, "pull0": true,
+	"pull1": true, "rcmos": true, "cmos": true, "time": true, "real": true,
+}
+
+// verilogName escapes names which are not Verilog identifiers.
+func verilogName(name string) string {
+	if verilogKeywords[name] || name[0] >= '0' && name[0] <= '9' {
+		return `\` + name + " "
+	}
+	return name
+}
+
+// port is a module input or output: a bus of numbered wires, or one wire.
+type port struct {
+	name  string
+	wires []int
+}
+
+// ports groups the wires which match pick into buses where they can be, so
+// x00..x44 becomes the port x[44:0].
+func (c *Circuit) ports(pick func(wire int) bool) []port {
+	ports := make([]port, 0)
+	done := make(map[int]bool)
+
+	picked := make([]int, 0)
+	for wire := range c.names {
+		if pick(wire) {
+			picked = append(picked, wire)
+		}
+	}
+
+	for _, wire := range c.sortByName(picked) {
+		if done[wire] {
+			continue
+		}
+
+		// A name which is all digits has no prefix to name a bus after.
+		if prefix, number := splitNumber(c.names[wire]); number >= 0 && prefix != "" && !c.hasName(prefix) {
+			if bus, err := c.Bus(prefix); err == nil && !slices.ContainsFunc(bus, func(w int) bool { return !pick(w) }) {
+				for _, w := range bus {
+					done[w] = true
+				}
+				ports = append(ports, port{name: prefix, wires: bus})
+				continue
+			}
+		}
+
+		done[wire] = true
+		ports = append(ports, port{name: c.names[wire], wires: []int{wire}})
+	}
+
+	return ports
+}
+
+func (c *Circuit) hasName(name string) bool {
+	_, ok := c.index[name]
+	return ok
+}
+
+// WriteVerilog writes the circuit as a structural Verilog module built from
+// and, or and xor primitives. The wires no gate drives are its inputs, and
+// the ones no gate reads its outputs; numbered wires become buses, so z07 is
+// z[7]. The values the netlist gives the inputs are listed in a comment.
+func (c *Circuit) WriteVerilog(w io.Writer, module string) error {
+	out := bufio.NewWriter(w)
+
+	read := make([]bool, len(c.names))
+	for _, g := range c.Gates {
+		read[g.Left], read[g.Right] = true, true
+	}
+
+	inputs := c.ports(func(wire int) bool { return c.driver[wire] < 0 })
+	outputs := c.ports(func(wire int) bool { return c.driver[wire] >= 0 && !read[wire] })
+
+	// ref is how each wire is written in the body of the module.
+	ref := make([]string, len(c.names))
+	for wire, name := range c.names {
+		ref[wire] = verilogName(name)
+	}
+
+	declaration := func(direction string, p port) string {
+		if len(p.wires) == 1 && p.name == c.names[p.wires[0]] {
+			return fmt.Sprintf("%s %s", direction, verilogName(p.name))
+		}
+		for bit, wire := range p.wires {
+			ref[wire] = fmt.Sprintf("%s[%d]", verilogName(p.name), bit)
+		}
+		return fmt.Sprintf("%s [%d:0] %s", direction, len(p.wires)-1, verilogName(p.name))
+	}
+
+	ports := make([]string, 0, len(inputs)+len(outputs))
+	for _, p := range inputs {
+		ports = append(ports, declaration("input", p))
+	}
+	for _, p := range outputs {
+		ports = append(ports, declaration("output", p))
+	}
+
+	fmt.Fprintf(out, "// The inputs were given:\n")
+	for _, p := range inputs {
+		value := make([]byte, len(p.wires))
+		for bit, wire := range p.wires {
+			value[len(p.wires)-1-bit] = "01"[boolToInt(c.initial[wire])]
+		}
+		fmt.Fprintf(out, "//   %s = %d'b%s\n", p.name, len(p.wires), value)
+	}
+	fmt.Fprintf(out, "module %s (\n\t%s\n);\n", verilogName(module), strings.Join(ports, ",\n\t"))
+
+	internal := make([]int, 0)
+	for wire := range c.names {
+		if c.driver[wire] >= 0 && read[wire] {
+			internal = append(internal, wire)
+		}
+	}
+	if len(internal) > 0 {
+		fmt.Fprintln(out)
+		for _, wire := range c.sortByName(internal) {
+			fmt.Fprintf(out, "\twire %s;\n", ref[wire])
+		}
+	}
+
+	fmt.Fprintln(out)
+	for i, g := range c.Gates {
+		fmt.Fprintf(out, "\t%s g$%d (%s, %s, %s);\n", strings.ToLower(g.Op.String()), i, ref[g.Output], ref[g.Left], ref[g.Right])
+	}
+
+	fmt.Fprintln(out, "endmodule")
+	return out.Flush()
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,107 @@
+package circuit
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWriteVerilog(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/example-1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := c.WriteVerilog(&out, "example"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `// The inputs were given:
+//   x = 3'b111
+//   y = 3'b010
+module example (
+	input [2:0] x,
+	input [2:0] y,
+	output [2:0] z
+);
+
+	and g$0 (z[0], x[0], y[0]);
+	xor g$1 (z[1], x[1], y[1]);
+	or g$2 (z[2], x[2], y[2]);
+endmodule
+`
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+// TestWriteVerilogNames checks that wires which are not buses stay as they
+// are, and names Verilog would not accept are escaped.
+func TestWriteVerilogNames(t *testing.T) {
+	c, err := Parse([]byte("a: 1\nx00: 0\nx02: 1\n\na AND x00 -> and\nand XOR x02 -> 7up\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := c.WriteVerilog(&out, "names"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"input a,", "input x00,", "input x02,", `output \7up `, `wire \and ;`, `xor g$1 (\7up , \and , x02);`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("no %q in\n%s", want, out.String())
+		}
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/example-2.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	z09, _ := c.Wire("z09")
+
+	var out strings.Builder
+	if err := c.WriteDOT(&out, []int{z09}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"subgraph cluster_4 {",
+		`"z09" [label="XOR\nz09", style=filled, fillcolor=salmon, color=red];`,
+		`"qhw" -> "z09";`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("no %q in\n%s", want, out.String())
+		}
+	}
+}
+
+// TestWriteVerilogDigits checks that wires named only with digits are not
+// taken for a bus with an empty name.
+func TestWriteVerilogDigits(t *testing.T) {
+	c, err := Parse([]byte("0: 0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := c.WriteVerilog(&out, "digits"); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `input \0 `; !strings.Contains(out.String(), want) {
+		t.Errorf("no %q in\n%s", want, out.String())
+	}
+}
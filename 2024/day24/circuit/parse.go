@@ -0,0 +1,124 @@
+package circuit
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Parse reads a netlist: lines of `wire: 0` giving the inputs' values, then
+// lines of `left OP right -> output` for the gates. Every wire must be either
+// an input or driven by exactly one gate.
+func Parse(raw []byte) (*Circuit, error) {
+	c := &Circuit{index: make(map[string]int)}
+
+	values := make(map[int]bool)
+	gates := make([]Gate, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case text == "":
+			continue
+
+		case strings.Contains(text, ":"):
+			name, value, _ := strings.Cut(text, ":")
+			name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+			if !validName(name) || (value != "0" && value != "1") {
+				return nil, fmt.Errorf("line %d: expected `wire: 0` or `wire: 1`, got %q", line, text)
+			}
+
+			wire := c.intern(name)
+			if _, seen := values[wire]; seen {
+				return nil, fmt.Errorf("line %d: %s is given a value twice", line, name)
+			}
+			values[wire] = value == "1"
+
+		default:
+			fields := strings.Fields(text)
+			if len(fields) != 5 || fields[3] != "->" {
+				return nil, fmt.Errorf("line %d: expected `left OP right -> output`, got %q", line, text)
+			}
+
+			op, err := parseOp(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			for _, name := range []string{fields[0], fields[2], fields[4]} {
+				if !validName(name) {
+					return nil, fmt.Errorf("line %d: invalid wire name %q", line, name)
+				}
+			}
+
+			gates = append(gates, Gate{
+				Op:     op,
+				Left:   c.intern(fields[0]),
+				Right:  c.intern(fields[2]),
+				Output: c.intern(fields[4]),
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	c.initial = make([]bool, len(c.names))
+	for wire, value := range values {
+		c.initial[wire] = value
+	}
+
+	if err := c.sort(gates); err != nil {
+		return nil, err
+	}
+
+	for wire, gate := range c.driver {
+		_, given := values[wire]
+		switch {
+		case gate < 0 && !given:
+			return nil, fmt.Errorf("wire %s is read, but has no gate or value", c.names[wire])
+		case gate >= 0 && given:
+			return nil, fmt.Errorf("wire %s is driven by %s, but is also given a value", c.names[wire], c.Format(c.Gates[gate]))
+		}
+	}
+
+	c.Reset()
+	return c, nil
+}
+
+func parseOp(text string) (Op, error) {
+	for op, name := range opNames {
+		if text == name {
+			return Op(op), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown gate %q", text)
+}
+
+// validName allows any name which cannot be mistaken for the rest of the
+// line: letters, digits and underscores.
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Circuit) intern(name string) int {
+	if wire, ok := c.index[name]; ok {
+		return wire
+	}
+	c.index[name] = len(c.names)
+	c.names = append(c.names, name)
+	return len(c.names) - 1
+}
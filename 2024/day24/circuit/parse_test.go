@@ -0,0 +1,53 @@
+package circuit
+
+import (
+	"io"
+	"os"
+	"testing"
+)
+
+// FuzzParse checks that Parse rejects anything it cannot simulate, and that
+// what it accepts has its gates in an order they can be evaluated in.
+func FuzzParse(f *testing.F) {
+	for _, file := range []string{"../testdata/example-1.txt", "../testdata/example-2.txt"} {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(raw)
+	}
+
+	// A loop, an undriven wire, a wire driven twice, and one both given and
+	// driven.
+	f.Add([]byte("x00: 1\n\nx00 AND b -> a\nx00 OR a -> b\n"))
+	f.Add([]byte("x00: 1\n\nx00 AND y00 -> z00\n"))
+	f.Add([]byte("x00: 1\n\nx00 AND x00 -> z00\nx00 OR x00 -> z00\n"))
+	f.Add([]byte("x00: 1\nz00: 0\n\nx00 AND x00 -> z00\n"))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		c, err := Parse(raw)
+		if err != nil {
+			return
+		}
+
+		for i, gate := range c.Gates {
+			if c.driver[gate.Output] != i {
+				t.Fatalf("%s is driven by gate %d, not %d", c.Format(gate), c.driver[gate.Output], i)
+			}
+			for _, input := range []int{gate.Left, gate.Right} {
+				if c.driver[input] >= i {
+					t.Fatalf("%s reads %s before it is driven", c.Format(gate), c.Name(input))
+				}
+			}
+		}
+
+		c.Evaluate()
+
+		if err := c.WriteVerilog(io.Discard, "fuzz"); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.WriteDOT(io.Discard, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package circuit
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Reset puts the inputs back to the values the netlist gave them, and
+// evaluates the circuit.
+func (c *Circuit) Reset() {
+	c.values = slices.Clone(c.initial)
+	c.Evaluate()
+}
+
+// Evaluate works out every wire from the inputs. It needs calling again after
+// any input is changed.
+func (c *Circuit) Evaluate() {
+	values := c.values
+	for _, g := range c.Gates {
+		values[g.Output] = g.Op.Apply(values[g.Left], values[g.Right])
+	}
+}
+
+// Value is the wire's value as of the last evaluation.
+func (c *Circuit) Value(wire int) bool {
+	return c.values[wire]
+}
+
+// Set changes an input wire. Setting a wire driven by a gate would only last
+// until the next evaluation, so is an error.
+func (c *Circuit) Set(wire int, value bool) error {
+	if c.driver[wire] >= 0 {
+		return fmt.Errorf("wire %s is driven by %s, so cannot be set", c.names[wire], c.Format(c.Gates[c.driver[wire]]))
+	}
+	c.values[wire] = value
+	return nil
+}
+
+// Bus is the wires whose names are prefix followed by a number, least
+// significant (numbered 0) first. Every number up to the width must be
+// there, but any prefix and width will do, so x00..x44 is bus x of width 45.
+func (c *Circuit) Bus(prefix string) ([]int, error) {
+	bits := make(map[int]int)
+	for wire, name := range c.names {
+		digits, ok := strings.CutPrefix(name, prefix)
+		if !ok || digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+			continue
+		}
+		bit, err := strconv.Atoi(digits)
+		if err != nil {
+			return nil, fmt.Errorf("bus %s: %w", prefix, err)
+		}
+		if other, ok := bits[bit]; ok {
+			return nil, fmt.Errorf("bus %s: %s and %s are both bit %d", prefix, c.names[other], name, bit)
+		}
+		bits[bit] = wire
+	}
+
+	if len(bits) == 0 {
+		return nil, fmt.Errorf("there is no bus %s", prefix)
+	}
+
+	bus := make([]int, len(bits))
+	for i := range bus {
+		wire, ok := bits[i]
+		if !ok {
+			return nil, fmt.Errorf("bus %s has %d wires, but no bit %d", prefix, len(bits), i)
+		}
+		bus[i] = wire
+	}
+
+	return bus, nil
+}
+
+// SetBus sets the wires of the bus to the bits of value. It does not
+// evaluate the circuit, so several buses can be set first.
+func (c *Circuit) SetBus(prefix string, value *big.Int) error {
+	bus, err := c.Bus(prefix)
+	if err != nil {
+		return err
+	}
+	if err := c.SetWires(bus, value); err != nil {
+		return fmt.Errorf("bus %s: %w", prefix, err)
+	}
+	return nil
+}
+
+// SetWires is SetBus for a bus already looked up, which saves finding the
+// wires again when setting it many times.
+func (c *Circuit) SetWires(bus []int, value *big.Int) error {
+	if value.Sign() < 0 || value.BitLen() > len(bus) {
+		return fmt.Errorf("%d does not fit in %d bits", value, len(bus))
+	}
+
+	for i, wire := range bus {
+		if err := c.Set(wire, value.Bit(i) == 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadBus is the value on the bus as of the last evaluation.
+func (c *Circuit) ReadBus(prefix string) (*big.Int, error) {
+	bus, err := c.Bus(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return c.ReadWires(bus), nil
+}
+
+// ReadWires is ReadBus for a bus already looked up.
+func (c *Circuit) ReadWires(bus []int) *big.Int {
+	value := new(big.Int)
+	for i, wire := range bus {
+		if c.values[wire] {
+			value.SetBit(value, i, 1)
+		}
+	}
+	return value
+}
+
+// SetBusUint64 is SetBus for buses up to 64 bits wide.
+func (c *Circuit) SetBusUint64(prefix string, value uint64) error {
+	return c.SetBus(prefix, new(big.Int).SetUint64(value))
+}
+
+// ReadBusUint64 is ReadBus for buses up to 64 bits wide.
+func (c *Circuit) ReadBusUint64(prefix string) (uint64, error) {
+	value, err := c.ReadBus(prefix)
+	if err != nil {
+		return 0, err
+	}
+	if !value.IsUint64() {
+		return 0, fmt.Errorf("bus %s holds %d, which is more than 64 bits", prefix, value)
+	}
+	return value.Uint64(), nil
+}
+
+func (c *Circuit) sortByName(wires []int) []int {
+	slices.SortFunc(wires, func(a, b int) int {
+		return cmp.Compare(c.names[a], c.names[b])
+	})
+	return wires
+}
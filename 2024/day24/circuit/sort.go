@@ -0,0 +1,129 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrCycle = errors.New("circuit has a loop")
+
+// sort puts the gates in order, so that each comes after the gates driving
+// its inputs, and records which gate drives each wire. This is Kahn's
+// algorithm: repeatedly take the gates whose inputs are all known. Any left
+// over are part of, or depend on, a loop.
+func (c *Circuit) sort(gates []Gate) error {
+	driver := make([]int, len(c.names))
+	for wire := range driver {
+		driver[wire] = -1
+	}
+	for i, g := range gates {
+		if driver[g.Output] >= 0 {
+			return fmt.Errorf("wire %s is driven by both %s and %s", c.names[g.Output], c.Format(gates[driver[g.Output]]), c.Format(g))
+		}
+		driver[g.Output] = i
+	}
+
+	// waiting is how many inputs of each gate are not yet known, and readers
+	// the gates reading each wire.
+	waiting := make([]int, len(gates))
+	readers := make([][]int, len(c.names))
+	ready := make([]int, 0)
+
+	for i, g := range gates {
+		for _, input := range []int{g.Left, g.Right} {
+			if driver[input] >= 0 {
+				waiting[i]++
+				readers[input] = append(readers[input], i)
+			}
+		}
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	sorted := make([]Gate, 0, len(gates))
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, gates[i])
+
+		for _, reader := range readers[gates[i].Output] {
+			if waiting[reader]--; waiting[reader] == 0 {
+				ready = append(ready, reader)
+			}
+		}
+	}
+
+	if len(sorted) < len(gates) {
+		for i := range gates {
+			if waiting[i] > 0 {
+				return fmt.Errorf("%w: %s", ErrCycle, c.findCycle(gates, driver, waiting, i))
+			}
+		}
+	}
+
+	c.Gates = sorted
+	c.driver = make([]int, len(c.names))
+	for wire := range c.driver {
+		c.driver[wire] = -1
+	}
+	for i, g := range c.Gates {
+		c.driver[g.Output] = i
+	}
+
+	return nil
+}
+
+// findCycle walks back from a gate which could not be sorted, always through
+// an input which could not be worked out either, until it comes back to a
+// wire it has already seen. The wires from there on are the loop.
+func (c *Circuit) findCycle(gates []Gate, driver, waiting []int, start int) string {
+	seen := make(map[int]int)
+	path := make([]int, 0)
+
+	for gate := start; ; {
+		wire := gates[gate].Output
+		if at, ok := seen[wire]; ok {
+			path = path[at:]
+			break
+		}
+		seen[wire] = len(path)
+		path = append(path, wire)
+
+		// Gates still waiting always have an input driven by another gate
+		// still waiting.
+		next := driver[gates[gate].Left]
+		if next < 0 || waiting[next] == 0 {
+			next = driver[gates[gate].Right]
+		}
+		gate = next
+	}
+
+	// The walk went from outputs to inputs, so turn it round to follow the
+	// signal.
+	slices.Reverse(path)
+	names := make([]string, 0, len(path)+1)
+	for _, wire := range path {
+		names = append(names, c.names[wire])
+	}
+	names = append(names, names[0])
+
+	return strings.Join(names, " -> ")
+}
+
+// Swap exchanges the outputs of the gates driving two wires, and sorts the
+// gates again. If that would make a loop, the circuit is left as it was. The
+// circuit needs evaluating again afterwards.
+func (c *Circuit) Swap(a, b int) error {
+	first, second := c.driver[a], c.driver[b]
+	if first < 0 || second < 0 {
+		return fmt.Errorf("cannot swap %s and %s, as only gates' outputs can be swapped", c.names[a], c.names[b])
+	}
+
+	gates := slices.Clone(c.Gates)
+	gates[first].Output, gates[second].Output = b, a
+
+	return c.sort(gates)
+}
@@ -0,0 +1,24 @@
+package day24
+
+import (
+	utils "tea-cats.co.uk/aoc/2024"
+	"tea-cats.co.uk/aoc/2024/day24/circuit"
+	"time"
+)
+
+const debug = false
+
+type Input struct {
+	Circuit *circuit.Circuit
+}
+
+func LoadData(raw []byte) (Input, error) {
+	defer utils.TimeTrack(time.Now(), "loadData")
+
+	c, err := circuit.Parse(raw)
+	if err != nil {
+		return Input{}, err
+	}
+
+	return Input{Circuit: c}, nil
+}
@@ -0,0 +1,10 @@
+package day24
+
+import (
+	"context"
+)
+
+// Part1 is the number on the z wires once the circuit has settled.
+func Part1(_ context.Context, input Input) (any, error) {
+	return input.Circuit.ReadBus("z")
+}
@@ -0,0 +1,40 @@
+package day24
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"tea-cats.co.uk/aoc/2024/day24/circuit"
+)
+
+// Part2 repairs the adder, and lists the wires which had to be swapped,
+// sorted and joined with commas.
+func Part2(ctx context.Context, input Input) (any, error) {
+	swapped, err := Repair(ctx, input.Circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Join(swapped, ","), nil
+}
+
+// Repair swaps wires in the circuit until it is a ripple-carry adder of x
+// and y into z, returning the names of the wires it swapped, sorted.
+func Repair(ctx context.Context, c *circuit.Circuit) ([]string, error) {
+	// The seed only picks the random additions which check each bit, so any
+	// will do, but a fixed one means every run does the same work.
+	r, err := newRepairer(c, rand.New(rand.NewPCG(24, 2)))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.Repair(ctx); err != nil {
+		return nil, err
+	}
+
+	names := r.names()
+	slices.Sort(names)
+
+	return names, nil
+}
@@ -0,0 +1,339 @@
+package day24
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"tea-cats.co.uk/aoc/2024/day24/circuit"
+	"tea-cats.co.uk/aoc/runner"
+)
+
+var ErrNotAdder = errors.New("circuit cannot be repaired into a ripple-carry adder")
+
+// maxSwapsPerBit stops a bit which keeps proposing swaps without settling.
+// A full adder has five gates, so more than this would be swapping gates
+// back and forth.
+const maxSwapsPerBit = 4
+
+// repairer matches a circuit bit by bit against a ripple-carry adder of x
+// and y into z, swapping gate outputs until each bit is right:
+//
+//	bit 0:  Z = X XOR Y,    Cout = X AND Y
+//	bit i:  S = X XOR Y,    G = X AND Y
+//	        Z = S XOR Cin,  P = S AND Cin,  Cout = G OR P
+//
+// with the carry out of the top bit being the extra z wire. Swaps only move
+// gate outputs, so the gates reading X and Y are always the right gates; the
+// rest are found by which wires they read, starting from those.
+type repairer struct {
+	c       *circuit.Circuit
+	x, y, z []int
+	rng     *rand.Rand
+
+	// Swaps are the pairs of wires swapped so far.
+	Swaps [][2]int
+}
+
+func newRepairer(c *circuit.Circuit, rng *rand.Rand) (*repairer, error) {
+	r := &repairer{c: c, rng: rng}
+
+	var err error
+	if r.x, err = c.Bus("x"); err != nil {
+		return nil, err
+	}
+	if r.y, err = c.Bus("y"); err != nil {
+		return nil, err
+	}
+	if r.z, err = c.Bus("z"); err != nil {
+		return nil, err
+	}
+
+	if len(r.y) != len(r.x) || len(r.z) != len(r.x)+1 {
+		return nil, fmt.Errorf("%w: x, y and z are %d, %d and %d bits wide", ErrNotAdder, len(r.x), len(r.y), len(r.z))
+	}
+
+	return r, nil
+}
+
+// Repair fixes every bit in turn, then checks the whole adder.
+func (r *repairer) Repair(ctx context.Context) error {
+	carry := -1
+
+	for bit := range r.x {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		runner.Report(ctx, "checking bit %d of %d, %d swaps so far", bit, len(r.x), len(r.Swaps))
+
+		next, err := r.repairBit(bit, carry)
+		if err != nil {
+			return err
+		}
+		carry = next
+	}
+
+	if ok, failure := r.verify(len(r.x)-1, carry); !ok {
+		return fmt.Errorf("%w: after swapping %s, %s", ErrNotAdder, r.names(), failure)
+	}
+
+	return nil
+}
+
+// repairBit swaps wires until one bit matches the template and adds up, and
+// returns its carry out.
+func (r *repairer) repairBit(bit, carry int) (int, error) {
+	for range maxSwapsPerBit + 1 {
+		next, proposal, err := r.match(bit, carry)
+		if err != nil {
+			break
+		}
+
+		if proposal == nil {
+			if ok, _ := r.verify(bit, next); ok {
+				return next, nil
+			}
+			break
+		}
+
+		if err := r.swap(*proposal, &carry); err != nil {
+			break
+		}
+	}
+
+	// The structure did not give an answer, so try every swap between the
+	// wires near this bit, and keep the first which makes it work.
+	return r.search(bit, carry)
+}
+
+// swap applies and records a swap. The gate which drove the carry in might
+// be one of those swapped, in which case the carry is now the other wire.
+func (r *repairer) swap(pair [2]int, carry *int) error {
+	if err := r.c.Swap(pair[0], pair[1]); err != nil {
+		return err
+	}
+
+	r.Swaps = append(r.Swaps, pair)
+	switch *carry {
+	case pair[0]:
+		*carry = pair[1]
+	case pair[1]:
+		*carry = pair[0]
+	}
+
+	if debug {
+		fmt.Printf("swapped %s and %s\n", r.c.Name(pair[0]), r.c.Name(pair[1]))
+	}
+	return nil
+}
+
+// match looks for the gates of one bit. If they are all there, it returns
+// the carry out. If a gate reads or drives the wrong wire, it returns the
+// swap which would put that right.
+func (r *repairer) match(bit, carry int) (int, *[2]int, error) {
+	x, y, z := r.x[bit], r.y[bit], r.z[bit]
+	last := bit == len(r.x)-1
+
+	sum, ok := r.find(circuit.XOR, x, y)
+	if !ok {
+		return 0, nil, fmt.Errorf("no %s XOR %s", r.c.Name(x), r.c.Name(y))
+	}
+	generate, ok := r.find(circuit.AND, x, y)
+	if !ok {
+		return 0, nil, fmt.Errorf("no %s AND %s", r.c.Name(x), r.c.Name(y))
+	}
+
+	if bit == 0 {
+		if sum.Output != z {
+			return 0, &[2]int{sum.Output, z}, nil
+		}
+		if last && generate.Output != r.z[bit+1] {
+			return 0, &[2]int{generate.Output, r.z[bit+1]}, nil
+		}
+		return generate.Output, nil, nil
+	}
+
+	// Z = S XOR Cin. If there is no such gate, the one which reads one of
+	// them has the wire which should be the other.
+	output, ok := r.find(circuit.XOR, sum.Output, carry)
+	if !ok {
+		proposal, err := r.misread(circuit.XOR, sum.Output, carry)
+		return 0, proposal, err
+	}
+	if output.Output != z {
+		return 0, &[2]int{output.Output, z}, nil
+	}
+
+	propagate, ok := r.find(circuit.AND, sum.Output, carry)
+	if !ok {
+		proposal, err := r.misread(circuit.AND, sum.Output, carry)
+		return 0, proposal, err
+	}
+
+	carryOut, ok := r.find(circuit.OR, generate.Output, propagate.Output)
+	if !ok {
+		proposal, err := r.misread(circuit.OR, generate.Output, propagate.Output)
+		return 0, proposal, err
+	}
+
+	if last && carryOut.Output != r.z[bit+1] {
+		return 0, &[2]int{carryOut.Output, r.z[bit+1]}, nil
+	}
+	// Only the top carry may be a z wire; anything else belongs to another
+	// bit, which will swap it back when it is checked.
+	return carryOut.Output, nil, nil
+}
+
+// misread proposes a swap for when no op gate reads both a and b: if a gate
+// of that kind reads one of them, its other input should be the other.
+func (r *repairer) misread(op circuit.Op, a, b int) (*[2]int, error) {
+	for _, g := range r.c.Gates {
+		if g.Op != op {
+			continue
+		}
+		switch {
+		case g.Left == a && g.Right != b:
+			return &[2]int{b, g.Right}, nil
+		case g.Right == a && g.Left != b:
+			return &[2]int{b, g.Left}, nil
+		case g.Left == b && g.Right != a:
+			return &[2]int{a, g.Right}, nil
+		case g.Right == b && g.Left != a:
+			return &[2]int{a, g.Left}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no %s gate reads %s or %s", op, r.c.Name(a), r.c.Name(b))
+}
+
+func (r *repairer) find(op circuit.Op, a, b int) (circuit.Gate, bool) {
+	for _, g := range r.c.Gates {
+		if g.Op == op && (g.Left == a && g.Right == b || g.Left == b && g.Right == a) {
+			return g, true
+		}
+	}
+	return circuit.Gate{}, false
+}
+
+// search tries swapping each pair of wires driven by gates within a few
+// steps of the bit's inputs and carry in, keeping the first which makes the
+// bit match and add up.
+func (r *repairer) search(bit, carry int) (int, error) {
+	near := r.near(bit, carry)
+
+	for i, a := range near {
+		for _, b := range near[i+1:] {
+			if r.c.Swap(a, b) != nil {
+				continue
+			}
+
+			moved := carry
+			switch carry {
+			case a:
+				moved = b
+			case b:
+				moved = a
+			}
+
+			if next, proposal, err := r.match(bit, moved); err == nil && proposal == nil {
+				if ok, _ := r.verify(bit, next); ok {
+					r.Swaps = append(r.Swaps, [2]int{a, b})
+					return next, nil
+				}
+			}
+
+			if err := r.c.Swap(a, b); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("%w: no single swap fixes bit %d", ErrNotAdder, bit)
+}
+
+// near is the wires driven by gates up to three gates on from the bit's
+// inputs or its carry in.
+func (r *repairer) near(bit, carry int) []int {
+	seen := make(map[int]bool)
+	frontier := []int{r.x[bit], r.y[bit]}
+	if carry >= 0 {
+		frontier = append(frontier, carry)
+	}
+
+	wires := make([]int, 0)
+	for range 3 {
+		next := make([]int, 0)
+		for _, wire := range frontier {
+			for _, g := range r.c.Readers(wire) {
+				if !seen[g.Output] {
+					seen[g.Output] = true
+					wires = append(wires, g.Output)
+					next = append(next, g.Output)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	// Bits' z wires are read by nothing, so add this bit's in case its gate
+	// has been swapped out of reach.
+	if !seen[r.z[bit]] {
+		wires = append(wires, r.z[bit])
+	}
+
+	return wires
+}
+
+// verify adds numbers which only use bits up to bit, and checks the z wires
+// up to that bit and the carry out. The numbers are the edge cases which
+// exercise each bit and the carries between them, then some random ones.
+func (r *repairer) verify(bit int, carry int) (bool, string) {
+	one := big.NewInt(1)
+	mask := new(big.Int).Sub(new(big.Int).Lsh(one, uint(bit+1)), one)
+
+	cases := [][2]*big.Int{
+		{new(big.Int), new(big.Int)},
+		{mask, new(big.Int)},
+		{new(big.Int), mask},
+		{mask, one},
+		{one, mask},
+		{mask, mask},
+	}
+	for i := range bit + 1 {
+		single := new(big.Int).Lsh(one, uint(i))
+		cases = append(cases, [2]*big.Int{single, single}, [2]*big.Int{single, new(big.Int)}, [2]*big.Int{new(big.Int), single})
+	}
+	for range 32 {
+		x, y := new(big.Int), new(big.Int)
+		for i := range bit + 1 {
+			x.SetBit(x, i, uint(r.rng.IntN(2)))
+			y.SetBit(y, i, uint(r.rng.IntN(2)))
+		}
+		cases = append(cases, [2]*big.Int{x, y})
+	}
+
+	for _, test := range cases {
+		x, y := test[0], test[1]
+		if r.c.SetWires(r.x, x) != nil || r.c.SetWires(r.y, y) != nil {
+			return false, "the inputs cannot be set"
+		}
+		r.c.Evaluate()
+
+		sum := new(big.Int).Add(x, y)
+		got := r.c.ReadWires(r.z[:bit+1])
+		if got.Cmp(new(big.Int).And(sum, mask)) != 0 || r.c.Value(carry) != (sum.Bit(bit+1) == 1) {
+			return false, fmt.Sprintf("%d + %d gives %d", x, y, r.c.ReadWires(r.z))
+		}
+	}
+
+	return true, ""
+}
+
+func (r *repairer) names() []string {
+	names := make([]string, 0, 2*len(r.Swaps))
+	for _, pair := range r.Swaps {
+		names = append(names, r.c.Name(pair[0]), r.c.Name(pair[1]))
+	}
+	return names
+}
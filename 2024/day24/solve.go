@@ -0,0 +1,7 @@
+package day24
+
+import "tea-cats.co.uk/aoc/runner"
+
+func init() {
+	runner.RegisterParsed(2024, 24, LoadData, Part1, Part2)
+}
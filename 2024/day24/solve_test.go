@@ -0,0 +1,75 @@
+package day24
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"tea-cats.co.uk/aoc/2024/day24/circuit"
+	"tea-cats.co.uk/aoc/2024/generate"
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/runner/runnertest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	runnertest.Examples(t, []runnertest.Example{
+		{Name: "part1", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part1), Want: "4"},
+		{Name: "part1-larger", File: "testdata/example-2.txt", Solve: runner.Parsed(LoadData, Part1), Want: "2024"},
+	})
+}
+
+// TestRepairGenerated repairs generated adders, and checks the wires swapped
+// are the ones which differ from the same adder generated without swaps.
+func TestRepairGenerated(t *testing.T) {
+	for seed := uint64(1); seed <= 100; seed++ {
+		bits := 2 + int(seed%50)
+		swaps := int(seed % 5)
+
+		raw := generate.Adder(generate.NewRand(seed), bits, swaps)
+		want := swappedWires(t, generate.Adder(generate.NewRand(seed), bits, 0), raw)
+
+		input, err := LoadData(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Part2(context.Background(), input)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if got != want {
+			t.Errorf("seed %d: got %s, want %s", seed, got, want)
+		}
+	}
+}
+
+// swappedWires lists the outputs which differ between the gates of two
+// netlists, matching gates by their operation and inputs.
+func swappedWires(t *testing.T, original, swapped []byte) string {
+	outputs := func(raw []byte) map[string]string {
+		c, err := circuit.Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		gates := make(map[string]string)
+		for _, g := range c.Gates {
+			inputs := []string{c.Name(g.Left), c.Name(g.Right)}
+			slices.Sort(inputs)
+			gates[fmt.Sprint(inputs, g.Op)] = c.Name(g.Output)
+		}
+		return gates
+	}
+
+	before, after := outputs(original), outputs(swapped)
+	wires := make([]string, 0)
+	for gate, output := range after {
+		if before[gate] != output {
+			wires = append(wires, output)
+		}
+	}
+	slices.Sort(wires)
+
+	return strings.Join(wires, ",")
+}
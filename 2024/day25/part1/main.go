@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
 )
@@ -55,7 +54,7 @@ func main() {
 
 func loadData() ([]lock, []key) {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-25.txt")
+	dataFile, err := utils.OpenInput(25)
 
 	if err != nil {
 		panic(err)
@@ -63,29 +62,88 @@ func loadData() ([]lock, []key) {
 
 	defer utils.CloseWithLog(dataFile)
 
+	locks, keys, err := readSchematics(dataFile)
+	if err != nil {
+		panic(err)
+	}
+
+	return locks, keys
+}
+
+// readSchematics reads blank line separated schematics, checking that each
+// is a lock (columns filled from the top) or a key (filled from the bottom).
+func readSchematics(reader io.Reader) ([]lock, []key, error) {
 	buffer := make([]byte, totalBlobSize)
 
 	keys := make([]key, 0, 250)
 	locks := make([]lock, 0, 250)
 
-	for {
-		_, err := dataFile.Read(buffer)
+	for index := 0; ; index++ {
+		_, err := io.ReadFull(reader, buffer[:totalBlobSize-1])
+
+		if errors.Is(err, io.EOF) && index > 0 {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("schematic %d: %w", index, err)
+		}
 
+		isLock, err := checkSchematic(buffer)
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			panic(err)
+			return nil, nil, fmt.Errorf("schematic %d: %w", index, err)
 		}
 
-		if buffer[0] == '#' {
+		// The blank line between schematics (missing after the last one)
+		buffer[totalBlobSize-1] = '\n'
+		n, err := reader.Read(buffer[totalBlobSize-1:])
+		if n == 1 && buffer[totalBlobSize-1] != '\n' {
+			return nil, nil, fmt.Errorf("schematic %d: expected a blank line after it", index)
+		}
+
+		if isLock {
 			locks = append(locks, bufferToObj[lock](buffer))
 		} else {
 			keys = append(keys, bufferToObj[key](buffer))
 		}
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, nil, err
+		}
 	}
 
-	return locks, keys
+	return locks, keys, nil
+}
+
+// checkSchematic validates one schematic, not including the blank line after
+// it, and reports whether it is a lock.
+func checkSchematic(data []byte) (bool, error) {
+	isLock := data[0] == '#'
+
+	for i := 0; i < row*height; i++ {
+		c := data[i]
+		if i%row == cylinders {
+			if c != '\n' {
+				return false, fmt.Errorf("row %d is not %d wide", i/row, cylinders)
+			}
+			continue
+		}
+		if c != '#' && c != '.' {
+			return false, fmt.Errorf("unexpected %q at row %d", c, i/row)
+		}
+
+		// Locks go from # to . down each column, keys from . to #.
+		if i >= row && (c == '#') != (data[i-row] == '#') && (c == '#') == isLock {
+			return false, fmt.Errorf("column %d is not a lock or key pin", i%row)
+		}
+		if i < row && (c == '#') != isLock {
+			return false, errors.New("top row is not all the same")
+		}
+		if i >= row*(height-1) && (c == '#') == isLock {
+			return false, errors.New("bottom row is not the opposite of the top")
+		}
+	}
+
+	return isLock, nil
 }
 
 func bufferToObj[T key | lock](data []byte) T {
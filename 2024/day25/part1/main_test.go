@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"testing"
+)
+
+const example = `#####
+.####
+.####
+.####
+.#.#.
+.#...
+.....
+
+#####
+##.##
+.#.##
+...##
+...#.
+...#.
+.....
+
+.....
+#....
+#....
+#...#
+#.#.#
+#.###
+#####
+
+.....
+.....
+#.#..
+###..
+###.#
+###.#
+#####
+
+.....
+.....
+.....
+#....
+#.#..
+#.#.#
+#####
+`
+
+// FuzzReadSchematics checks that the loader never panics, and that anything
+// it accepts is turned back into the same kind of schematic.
+func FuzzReadSchematics(f *testing.F) {
+	log.SetOutput(io.Discard)
+
+	f.Add([]byte(example))
+	f.Add([]byte(example[:len(example)-1]))
+	f.Add([]byte(example[:43]))
+	f.Add([]byte(example[:20]))
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		locks, keys, err := readSchematics(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		if got, want := len(locks)+len(keys), (len(input)+1)/43; got != want {
+			t.Errorf("read %d schematics from %d bytes", got, len(input))
+		}
+
+		for _, l := range locks {
+			if isLock, err := checkSchematic([]byte(toStr(l))); !isLock || err != nil {
+				t.Errorf("lock %q does not round trip: %v", toStr(l), err)
+			}
+		}
+		for _, k := range keys {
+			if isLock, err := checkSchematic([]byte(toStr(k))); isLock || err != nil {
+				t.Errorf("key %q does not round trip: %v", toStr(k), err)
+			}
+		}
+	})
+}
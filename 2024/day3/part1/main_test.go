@@ -0,0 +1,29 @@
+package main
+
+import (
+	"io"
+	"log"
+	"testing"
+)
+
+// FuzzParse checks the hand-written parser against the regular expression on
+// arbitrary input.
+func FuzzParse(f *testing.F) {
+	log.SetOutput(io.Discard)
+
+	f.Add([]byte("xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))"))
+	f.Add([]byte("mul(123,456)mul(1234,5)mul(5,1234)mul(,5)mul(5,)"))
+
+	// Regressions found by fuzzing: an instruction ending the input was
+	// skipped, one that was cut off read past the end, and a failed match
+	// swallowed the 'm' of the instruction after it.
+	f.Add([]byte("mul(1,1)"))
+	f.Add([]byte("mul(111,111"))
+	f.Add([]byte("mmul(2,3)mul(mul(4,5)"))
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		if got, want := parse(input), regex(input); got != want {
+			t.Errorf("parse(%q) = %d, regex = %d", input, got, want)
+		}
+	})
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"io"
-	"os"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
 )
@@ -19,7 +18,7 @@ func main() {
 
 func loadData() []byte {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-3.txt")
+	dataFile, err := utils.OpenInput(3)
 
 	if err != nil {
 		panic(err)
@@ -43,7 +42,7 @@ func parse(input []byte) int {
 	active := true
 
 	// Minimum length of `mul(0,0)` is 8 bytes
-	stop := len(input) - 8
+	stop := len(input) - 7
 
 outer:
 	for i := 0; i < stop; i++ {
@@ -64,14 +63,19 @@ outer:
 		}
 		i++
 		if input[i] != 'u' {
+			// Step back so this byte is checked again, as it could be the
+			// start of the next instruction.
+			i--
 			continue
 		}
 		i++
 		if input[i] != 'l' {
+			i--
 			continue
 		}
 		i++
 		if input[i] != '(' {
+			i--
 			continue
 		}
 
@@ -80,6 +84,9 @@ outer:
 
 		for leftDigits = 0; leftDigits <= 3; leftDigits++ {
 			i++
+			if i == len(input) {
+				break outer
+			}
 			c := input[i]
 
 			if c == ',' {
@@ -87,13 +94,15 @@ outer:
 			}
 
 			if c < '0' || c > '9' {
+				i--
 				continue outer
 			}
 
 			leftOperand = leftOperand*10 + int(c-'0')
 		}
 
-		if input[i] != ',' {
+		if input[i] != ',' || leftDigits == 0 {
+			i--
 			continue
 		}
 
@@ -102,6 +111,9 @@ outer:
 
 		for rightDigits = 0; rightDigits <= 3; rightDigits++ {
 			i++
+			if i == len(input) {
+				break outer
+			}
 			c := input[i]
 
 			if c == ')' {
@@ -109,13 +121,15 @@ outer:
 			}
 
 			if c < '0' || c > '9' {
+				i--
 				continue outer
 			}
 
 			rightOperand = rightOperand*10 + int(c-'0')
 		}
 
-		if input[i] != ')' {
+		if input[i] != ')' || rightDigits == 0 {
+			i--
 			continue
 		}
 
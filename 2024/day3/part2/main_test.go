@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+var instruction = regexp.MustCompile(`mul\(([0-9]{1,3}),([0-9]{1,3})\)|do\(\)|don't\(\)`)
+
+// reference is the regular expression equivalent of parse.
+func reference(input []byte) int {
+	acc := 0
+	active := true
+
+	for _, match := range instruction.FindAllSubmatch(input, -1) {
+		switch string(match[0]) {
+		case "do()":
+			active = true
+		case "don't()":
+			active = false
+		default:
+			if active {
+				left, _ := strconv.Atoi(string(match[1]))
+				right, _ := strconv.Atoi(string(match[2]))
+				acc += left * right
+			}
+		}
+	}
+
+	return acc
+}
+
+// FuzzParse checks the hand-written parser against the regular expression on
+// arbitrary input.
+func FuzzParse(f *testing.F) {
+	log.SetOutput(io.Discard)
+
+	f.Add([]byte("xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))"))
+	f.Add([]byte("mul(123,456)don't()mul(1234,5)do()mul(5,1234)mul(,5)mul(5,)mul(1,1)"))
+
+	// Regressions found by fuzzing: an instruction ending the input was
+	// skipped, one that was cut off read past the end, and a failed match
+	// swallowed the 'm' of the instruction after it.
+	f.Add([]byte("mul(1,1)"))
+	f.Add([]byte("mul(111,111"))
+	f.Add([]byte("mmul(2,3)mul(mul(4,5)"))
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		if got, want := parse(input), reference(input); got != want {
+			t.Errorf("parse(%q) = %d, reference = %d", input, got, want)
+		}
+	})
+}
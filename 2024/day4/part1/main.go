@@ -3,7 +3,6 @@ package main
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
 )
@@ -20,7 +19,7 @@ const backwards uint32 = ('S' << 24) + ('A' << 16) + ('M' << 8) + ('X')
 
 func loadData() int {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-4.txt")
+	dataFile, err := utils.OpenInput(4)
 
 	if err != nil {
 		panic(err)
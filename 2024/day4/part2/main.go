@@ -3,7 +3,6 @@ package main
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
 )
@@ -20,7 +19,7 @@ const backwards = uint32('S'<<16) + uint32('A'<<8) + uint32('M')
 
 func loadData() int {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-4.txt")
+	dataFile, err := utils.OpenInput(4)
 
 	if err != nil {
 		panic(err)
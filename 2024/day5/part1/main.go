@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"io"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
@@ -41,7 +40,7 @@ nextRun:
 
 func loadData() (map[uint8][]uint8, [][]uint8) {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-5.txt")
+	dataFile, err := utils.OpenInput(5)
 
 	if err != nil {
 		panic(err)
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
@@ -62,7 +61,7 @@ func main() {
 
 func loadData() (map[uint8][]uint8, [][]uint8) {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-5.txt")
+	dataFile, err := utils.OpenInput(5)
 
 	if err != nil {
 		panic(err)
@@ -0,0 +1,77 @@
+package day6
+
+import (
+	"bytes"
+	"fmt"
+	utils "tea-cats.co.uk/aoc/2024"
+	"time"
+)
+
+const debug = false
+
+// size is the largest map that can be loaded.
+const size = 130
+
+type Direction uint8
+
+const (
+	North Direction = iota
+	East  Direction = iota
+	South Direction = iota
+	West  Direction = iota
+)
+
+type CellState uint8
+
+const (
+	Clear       CellState = iota
+	Obstruction CellState = iota
+	Visited     CellState = iota
+)
+
+type Point struct {
+	x, y uint8
+}
+
+// Input is the map of the lab, with the guard starting out facing north.
+type Input struct {
+	Maze Maze
+}
+
+func LoadData(raw []byte) (Input, error) {
+	defer utils.TimeTrack(time.Now(), "loadData")
+
+	maze := Maze{direction: North}
+	guards := 0
+
+	for row, line := range bytes.Split(bytes.TrimRight(raw, "\n"), []byte("\n")) {
+		if row >= size || len(line) > size {
+			return Input{}, fmt.Errorf("map is larger than %dx%d", size, size)
+		}
+		if row > 0 && len(line) != int(maze.width) {
+			return Input{}, fmt.Errorf("row %d is %d long, expected %d", row, len(line), maze.width)
+		}
+
+		maze.width = uint8(len(line))
+		maze.height = uint8(row + 1)
+
+		for col, char := range line {
+			switch char {
+			case '#':
+				maze.area[row][col] = Obstruction
+			case '^':
+				maze.guard = Point{x: uint8(col), y: uint8(row)}
+				guards++
+			case '.':
+			default:
+				return Input{}, fmt.Errorf("invalid map cell %q at %d,%d", char, col, row)
+			}
+		}
+	}
+
+	if guards != 1 {
+		return Input{}, fmt.Errorf("expected one guard, found %d", guards)
+	}
+
+	return Input{Maze: maze}, nil
+}
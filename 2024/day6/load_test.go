@@ -0,0 +1,43 @@
+package day6
+
+import (
+	"io"
+	"log"
+	"os"
+	"testing"
+)
+
+// FuzzLoadData checks that the loader rejects anything that is not a
+// rectangular map with one guard, and keeps what it accepts inside the area.
+// The parts are not run, as a guard stuck in a loop never leaves.
+func FuzzLoadData(f *testing.F) {
+	log.SetOutput(io.Discard)
+
+	raw, err := os.ReadFile("testdata/example-1.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(raw)
+	f.Add([]byte("^"))
+	f.Add([]byte("..\n.^\n..\n"))
+	f.Add([]byte("..\n^^\n"))
+	f.Add([]byte("...\n.^\n"))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		input, err := LoadData(raw)
+		if err != nil {
+			return
+		}
+
+		maze := input.Maze
+		if maze.width == 0 || maze.height == 0 || int(maze.width) > size || int(maze.height) > size {
+			t.Fatalf("accepted a %dx%d map", maze.width, maze.height)
+		}
+		if maze.guard.x >= maze.width || maze.guard.y >= maze.height {
+			t.Fatalf("the guard is at %v, outside the %dx%d map", maze.guard, maze.width, maze.height)
+		}
+		if maze.area[maze.guard.y][maze.guard.x] == Obstruction {
+			t.Fatalf("the guard is standing on an obstruction")
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package day6
+
+import (
+	"fmt"
+	utils "tea-cats.co.uk/aoc/2024"
+)
+
+type guardState struct {
+	position  Point
+	direction Direction
+}
+
+// escaped is outside the area, so can never be reached by walking.
+var escaped = guardState{position: Point{size, size}}
+
+type Maze struct {
+	area      [size][size]CellState
+	width     uint8
+	height    uint8
+	guard     Point
+	direction Direction
+}
+
+// move advances the guard by one step: either forward, or turning right
+// when blocked. Once the guard leaves the area, it stays escaped.
+func (maze *Maze) move(state guardState) guardState {
+	if state == escaped {
+		return escaped
+	}
+
+	var next Point
+
+	switch state.direction {
+	case North:
+		next = Point{state.position.x, state.position.y - 1}
+	case East:
+		next = Point{state.position.x + 1, state.position.y}
+	case South:
+		next = Point{state.position.x, state.position.y + 1}
+	case West:
+		next = Point{state.position.x - 1, state.position.y}
+	}
+
+	if next.x >= maze.width || next.y >= maze.height {
+		if debug {
+			fmt.Printf("Escaping at %v\n", next)
+		}
+		return escaped
+	}
+
+	if maze.area[next.y][next.x] != Obstruction {
+		if debug {
+			fmt.Printf("Moving %v to %v\n", state.direction, next)
+		}
+		return guardState{position: next, direction: state.direction}
+	}
+
+	if debug {
+		fmt.Printf("Encountered obstruction at %v, turning\n", next)
+	}
+
+	if state.direction == West {
+		return guardState{position: state.position, direction: North}
+	}
+	return guardState{position: state.position, direction: state.direction + 1}
+}
+
+// markVisited walks the guard out of the maze, marking every cell on the way,
+// and returns how many distinct cells that was.
+func (maze *Maze) markVisited() int {
+	visited := 0
+
+	for state := (guardState{maze.guard, maze.direction}); state != escaped; state = maze.move(state) {
+		if maze.area[state.position.y][state.position.x] != Visited {
+			maze.area[state.position.y][state.position.x] = Visited
+			visited++
+		}
+	}
+
+	return visited
+}
+
+func (maze *Maze) checkLoop() bool {
+	// If the guard escapes, the only cycle is the escaped state repeating itself.
+	cycle := utils.DetectCycleBrent(guardState{maze.guard, maze.direction}, maze.move)
+
+	if debug && cycle.StateAt(cycle.Start) != escaped {
+		fmt.Printf("Loop of length %d found after %d steps\n", cycle.Length, cycle.Start)
+	}
+
+	return cycle.StateAt(cycle.Start) != escaped
+}
+
+func (maze *Maze) Print() {
+	width := int(maze.width)
+	buffer := make([]byte, int(maze.height)*(width+1))
+
+	for row := 0; row < int(maze.height); row++ {
+		for col := 0; col < width; col++ {
+			switch maze.area[row][col] {
+			case Obstruction:
+				buffer[row*(width+1)+col] = '#'
+			case Visited:
+				buffer[row*(width+1)+col] = 'X'
+			case Clear:
+				buffer[row*(width+1)+col] = ' '
+			}
+		}
+		buffer[row*(width+1)+width] = '\n'
+	}
+
+	fmt.Println(string(buffer))
+}
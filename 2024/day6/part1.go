@@ -0,0 +1,15 @@
+package day6
+
+import "context"
+
+// Part1 counts the cells the guard visits before leaving the lab.
+func Part1(_ context.Context, input Input) (any, error) {
+	maze := input.Maze
+	visited := maze.markVisited()
+
+	if debug {
+		maze.Print()
+	}
+
+	return visited, nil
+}
@@ -0,0 +1,47 @@
+package day6
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tea-cats.co.uk/aoc/runner"
+)
+
+// Part2 counts the places a single extra obstruction would trap the guard in
+// a loop. Only cells on the guard's original route can make a difference.
+func Part2(ctx context.Context, input Input) (any, error) {
+	mazeWithoutExtraObstruction := input.Maze
+
+	// Find all cells the guard will naturally visit
+	if mazeWithoutExtraObstruction.checkLoop() {
+		return nil, errors.New("guard is already stuck in a loop")
+	}
+	mazeWithoutExtraObstruction.markVisited()
+
+	var i, j uint8
+	possibleLoop := 0
+
+	for i = 0; i < input.Maze.height; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		runner.Report(ctx, "row %d of %d, %d loops so far", i, input.Maze.height, possibleLoop)
+
+		for j = 0; j < input.Maze.width; j++ {
+			if mazeWithoutExtraObstruction.area[i][j] != Visited || (Point{x: j, y: i}) == input.Maze.guard {
+				continue
+			}
+
+			testMaze := input.Maze
+			testMaze.area[i][j] = Obstruction
+			if testMaze.checkLoop() {
+				possibleLoop++
+				if debug {
+					fmt.Printf("Maze contains loop with extra obstruction %v\n", Point{x: j, y: i})
+				}
+			}
+		}
+	}
+
+	return possibleLoop, nil
+}
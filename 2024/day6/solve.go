@@ -0,0 +1,7 @@
+package day6
+
+import "tea-cats.co.uk/aoc/runner"
+
+func init() {
+	runner.RegisterParsed(2024, 6, LoadData, Part1, Part2)
+}
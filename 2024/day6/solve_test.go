@@ -0,0 +1,14 @@
+package day6
+
+import (
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/runner/runnertest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	runnertest.Examples(t, []runnertest.Example{
+		{Name: "part1", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part1), Want: "41"},
+		{Name: "part2", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part2), Want: "6"},
+	})
+}
@@ -0,0 +1,58 @@
+package day7
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	utils "tea-cats.co.uk/aoc/2024"
+	"time"
+)
+
+const debug = false
+
+// Request is one calibration equation: operators need to be found to put
+// between the operands to produce the target.
+type Request struct {
+	Target   uint64
+	Operands []uint64
+}
+
+type Input struct {
+	Requests []Request
+}
+
+func LoadData(raw []byte) (Input, error) {
+	defer utils.TimeTrack(time.Now(), "loadData")
+
+	input := Input{}
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 || !strings.HasSuffix(fields[0], ":") {
+			return Input{}, fmt.Errorf("invalid equation %q", scanner.Text())
+		}
+
+		target, err := strconv.ParseUint(strings.TrimSuffix(fields[0], ":"), 10, 64)
+		if err != nil {
+			return Input{}, err
+		}
+		operands := make([]uint64, len(fields)-1)
+
+		for fieldNo, operand := range fields[1:] {
+			operands[fieldNo], err = strconv.ParseUint(operand, 10, 64)
+			if err != nil {
+				return Input{}, err
+			}
+		}
+
+		input.Requests = append(input.Requests, Request{Target: target, Operands: operands})
+	}
+
+	return input, scanner.Err()
+}
@@ -0,0 +1,83 @@
+package day7
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	utils "tea-cats.co.uk/aoc/2024"
+	"testing"
+)
+
+// FuzzLoadData checks that what the loader accepts prints back as the same
+// equations, and that both parts can work on it. Equations with many
+// operands are not solved, as part 1 tries every combination.
+func FuzzLoadData(f *testing.F) {
+	log.SetOutput(io.Discard)
+
+	raw, err := os.ReadFile("testdata/example-1.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(raw)
+	f.Add([]byte("5: 5\n"))
+	f.Add([]byte("0: 0 0\n"))
+	f.Add([]byte("18446744073709551615: 9999999999 9999999999\n"))
+	f.Add([]byte("12:\n"))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		input, err := LoadData(raw)
+		if err != nil {
+			return
+		}
+
+		var printed strings.Builder
+		for _, request := range input.Requests {
+			if len(request.Operands) == 0 {
+				t.Fatalf("%d has no operands", request.Target)
+			}
+			fmt.Fprintf(&printed, "%d:", request.Target)
+			for _, operand := range request.Operands {
+				fmt.Fprintf(&printed, " %d", operand)
+			}
+			printed.WriteByte('\n')
+		}
+
+		again, err := LoadData([]byte(printed.String()))
+		if err != nil {
+			t.Fatalf("%q does not load again: %v", printed.String(), err)
+		}
+		if fmt.Sprint(again) != fmt.Sprint(input) {
+			t.Fatalf("%q loads as %v, not %v", printed.String(), again, input)
+		}
+
+		// The answers are sums of targets, so are only compared if that
+		// cannot overflow.
+		total, fits := uint64(0), true
+		for _, request := range input.Requests {
+			if len(request.Operands) > 10 {
+				return
+			}
+			if fits {
+				total, fits = utils.AddChecked(total, request.Target)
+			}
+		}
+
+		ctx := context.Background()
+		first, err := Part1(ctx, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := Part2(ctx, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Concatenation only adds ways to reach the targets.
+		if fits && second.(uint64) < first.(uint64) {
+			t.Fatalf("part 2 gives %d, less than part 1's %d", second, first)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package day7
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	utils "tea-cats.co.uk/aoc/2024"
+)
+
+// Part1 tries every combination of + and * for each equation, as the bits
+// of a number, skipping all the combinations which start with a prefix that
+// has already overshot the target, with no 0 left to bring it back down.
+func Part1(ctx context.Context, input Input) (any, error) {
+	for _, row := range input.Requests {
+		if len(row.Operands) > 63 {
+			return nil, errors.New("invalid input -- too many operands")
+		}
+	}
+
+	validOptions := uint64(0)
+	variationsConsidered := uint64(0)
+	variationsPossible := uint64(0)
+
+nextNumber:
+	for _, row := range input.Requests {
+		length := uint16(len(row.Operands))
+		totalPermutations := uint64(1) << (length - 1)
+		variationsPossible += totalPermutations
+
+		// Multiplying by 0 brings a value back down, so a value past the
+		// target can only be given up on once every 0 has been used.
+		lastZero := uint16(0)
+		for i, operand := range row.Operands {
+			if operand == 0 {
+				lastZero = uint16(i)
+			}
+		}
+
+	nextPermutation:
+		for permutation := uint64(0); permutation < totalPermutations; permutation++ {
+			variationsConsidered++
+			rowAccumulator, overflowed := row.Operands[0], false
+			// We flip the order so that a known sequence e.g. 010101xxxxxx
+			// in the permutations is processed as xxxxxx010101 by the binary processing logic.
+			// This means that if we exceed the target value with the first set of operations,
+			// we can easily prune all operations that start with that sequence by skipping the
+			// rest of that block.
+			runPermutation := permutation
+			runPermutation = bits.Reverse64(permutation) >> (65 - length)
+
+			if debug {
+				fmt.Printf("%v variation %d\n", row, permutation)
+				fmt.Printf("  x = %d\n", rowAccumulator)
+			}
+
+			for field := uint16(1); field < length; field++ {
+				if (runPermutation & 1) != 0 {
+					if debug {
+						fmt.Printf("  x = %d + %d = %d\n", rowAccumulator, row.Operands[field], rowAccumulator+row.Operands[field])
+					}
+					if !overflowed {
+						var ok bool
+						rowAccumulator, ok = utils.AddChecked(rowAccumulator, row.Operands[field])
+						overflowed = !ok
+					}
+				} else {
+					if debug {
+						fmt.Printf("  x = %d * %d = %d\n", rowAccumulator, row.Operands[field], rowAccumulator*row.Operands[field])
+					}
+					if row.Operands[field] == 0 {
+						// However big the value was, it is 0 now.
+						rowAccumulator, overflowed = 0, false
+					} else if !overflowed {
+						var ok bool
+						rowAccumulator, ok = utils.MulChecked(rowAccumulator, row.Operands[field])
+						overflowed = !ok
+					}
+				}
+				// Overflowing is overshooting too.
+				if (overflowed || rowAccumulator > row.Target) && field >= lastZero {
+					// We're reading the binary string right -> left
+					// But it is the inversion of the outer loop
+					// So we are effectively reading `permutation` left -> right
+					fieldInOriginalPermutation := length - field - 1
+
+					// After `field` fields, we are out of bounds. Any combination of later fields
+					// will also terminate here. So we can prune all those branches.
+					// We achieve this by setting all the remaining bits high.
+					permutation = permutation | ((1 << fieldInOriginalPermutation) - 1)
+
+					// When iterating the loop, one more will be added to `permutation`, taking us
+					// out of this branch
+					continue nextPermutation
+				}
+				runPermutation = runPermutation >> 1
+			}
+
+			if !overflowed && rowAccumulator == row.Target {
+				if debug {
+					fmt.Printf("Valid!\n")
+				}
+				validOptions += row.Target
+				continue nextNumber
+			}
+		}
+	}
+
+	if debug {
+		fmt.Printf("Considered %d variations (%.1f%% of possible variations)\n", variationsConsidered, 100*(float64(variationsConsidered)/float64(variationsPossible)))
+	}
+
+	return validOptions, nil
+}
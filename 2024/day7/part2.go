@@ -0,0 +1,199 @@
+package day7
+
+import (
+	"context"
+	"fmt"
+	"math"
+	utils "tea-cats.co.uk/aoc/2024"
+)
+
+// Part2 works forwards through the operands, keeping every value that could
+// still reach the target given the bounds of what the remaining operands can
+// do to it.
+func Part2(ctx context.Context, input Input) (any, error) {
+	validOptions := uint64(0)
+
+	// Tracking information
+	potentialTrees := 0 // How many operations could have happened
+	trees := 0          // How many operations we evaluated
+	maxValues := 0      // How many values we were tracking at once
+	maxRowNum := 0      // The row on which the max values was reached
+
+	for rowNum, row := range input.Requests {
+		shiftFactors := make([]uint64, len(row.Operands))
+		potentialTrees += 3 * IntPow(3, len(row.Operands)-1) / 2
+
+		// Calculate the value for concatenating the values together.
+		// This is always the largest operator:
+		//   x || 9 = 10 * x + 9
+		//   x * 9 < 10x + 9
+		//   x + 9 < 10x + 9
+		// If it doesn't fit in a uint64 then it's certainly above the target.
+		conAcc := uint64(0)
+		conFits := true
+		for i, operand := range row.Operands {
+			// Calculate the multiplication factor for the || operator. Even
+			// a 0 is one digit: x || 0 = 10x.
+			shiftFactors[i] = 10
+			// A 20 digit operand needs a factor too big for a uint64, which
+			// is left as 0.
+			for buf := operand / 10; buf > 0 && shiftFactors[i] != 0; buf /= 10 {
+				factor, ok := utils.MulChecked(shiftFactors[i], 10)
+				if !ok {
+					factor = 0
+				}
+				shiftFactors[i] = factor
+			}
+
+			if conFits {
+				conAcc, conFits = concat(conAcc, shiftFactors[i], operand)
+			}
+		}
+
+		// Hey, if we get exactly the answer from concatenation, that's a free result
+		//  (My data set includes 0 of these)
+		if conFits && conAcc == row.Target {
+			validOptions += row.Target
+			continue
+		}
+
+		// And if we didn't make it to the target, that's a free negative result
+		if conFits && conAcc < row.Target {
+			continue
+		}
+
+		// With only one operand, there is nothing left to try
+		if len(row.Operands) == 1 {
+			continue
+		}
+
+		minimums := make([]uint64, len(row.Operands)-1)
+		maximums := make([]uint64, len(row.Operands)-1)
+		minTarget := row.Target
+		maxTarget := row.Target
+
+		// Calculate the minimum and maximum values at each
+		// position that could in theory still reach an answer
+		minimums[len(row.Operands)-2] = minTarget
+		maximums[len(row.Operands)-2] = maxTarget
+		for i := len(row.Operands) - 1; i > 1; i-- {
+			if row.Operands[i] == 0 {
+				// x*0 is 0 whatever x was, so any value before a 0 could
+				// still reach the target.
+				minTarget, maxTarget = 0, math.MaxUint64
+				minimums[i-2], maximums[i-2] = minTarget, maxTarget
+				continue
+			}
+
+			if shiftFactors[i] == 0 {
+				minTarget = 0
+			} else {
+				minTarget /= shiftFactors[i]
+			}
+			minimums[i-2] = minTarget
+
+			// The smallest a value can become is x+n or x*n, whichever is
+			// less, so the maximum is the larger of the two inverses. That is
+			// usually max-n, but max/n wins when n is close to max, and for a
+			// 1, where the maximum stays the same.
+			byAdding := uint64(0)
+			if maxTarget > row.Operands[i] {
+				byAdding = maxTarget - row.Operands[i]
+			}
+			maxTarget = max(byAdding, maxTarget/row.Operands[i])
+			maximums[i-2] = maxTarget
+		}
+
+		tracked := []uint64{row.Operands[0]}
+
+		for i, operand := range row.Operands[1:] {
+			toCheck := 3 * len(tracked)
+
+			// Keep track of which row had the most allocations.
+			trees += toCheck
+			if toCheck > maxValues {
+				maxValues = toCheck
+				maxRowNum = rowNum
+			}
+
+			// Pre-allocate the slice for the values we find at this step
+			out := make([]uint64, 0, toCheck+1)
+
+			// Get the minimum / maximum allocations
+			minimum := minimums[i]
+			maximum := maximums[i]
+
+			// A candidate which overflows is past any target, so is dropped
+			// just like one above the maximum.
+			for _, previous := range tracked {
+				next, ok := utils.AddChecked(previous, operand)
+				if ok && minimum <= next && next <= maximum {
+					out = append(out, next)
+				}
+
+				if operand != 0 {
+					next, ok = utils.MulChecked(previous, operand)
+					if ok && minimum <= next && next <= maximum {
+						out = append(out, next)
+					}
+				}
+
+				next, ok = concat(previous, shiftFactors[i+1], operand)
+				if ok && minimum <= next && next <= maximum {
+					out = append(out, next)
+				}
+			}
+
+			// Multiplying by 0 gives 0 just once, and does so even from the
+			// values which were dropped for overflowing.
+			if operand == 0 && minimum == 0 {
+				out = append(out, 0)
+			}
+
+			tracked = out
+		}
+
+		if len(tracked) > 0 {
+			validOptions += row.Target
+		}
+	}
+
+	if debug {
+		fmt.Printf("Evaluated %.1f%% of %d possible operations, with row %d having %d operations evaluated in one step\n", float64(trees)/float64(potentialTrees)*100, potentialTrees, maxRowNum, maxValues)
+	}
+
+	return validOptions, nil
+}
+
+func IntPow(base, exp int) int {
+	result := 1
+	for {
+		if exp&1 == 1 {
+			result *= base
+		}
+		exp >>= 1
+		if exp == 0 {
+			break
+		}
+		base *= base
+	}
+
+	return result
+}
+
+// concat is a || operand, given the power of ten above operand (0 if that
+// does not fit in a uint64), and whether the result fits.
+func concat(a, shift, operand uint64) (uint64, bool) {
+	if a == 0 {
+		return operand, true
+	}
+	if shift == 0 {
+		return 0, false
+	}
+
+	shifted, ok := utils.MulChecked(a, shift)
+	if !ok {
+		return 0, false
+	}
+	return utils.AddChecked(shifted, operand)
+}
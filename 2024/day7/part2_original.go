@@ -0,0 +1,117 @@
+package day7
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+type Operation uint8
+
+const (
+	add Operation = iota
+	mul Operation = iota
+	con Operation = iota
+	_   Operation = iota
+)
+
+// Part2Original is the first version of part 2: Part1 with two bits per
+// operator to fit in ||. It is kept to check Part2 against.
+func Part2Original(ctx context.Context, input Input) (any, error) {
+	for _, row := range input.Requests {
+		if len(row.Operands) > 32 {
+			return nil, errors.New("invalid input -- too many operands")
+		}
+	}
+
+	const bitsPerOperation = 2
+
+	validOptions := uint64(0)
+	variationsConsidered := uint64(0)
+	variationsPossible := uint64(0)
+
+nextNumber:
+	for _, row := range input.Requests {
+		length := uint16(len(row.Operands))
+		totalPermuatations := uint64(1) << ((bitsPerOperation * length) - 1)
+		variationsPossible += totalPermuatations
+	nextPermutation:
+		for permutation := uint64(0); permutation < totalPermuatations; permutation++ {
+			variationsConsidered++
+			rowAccumulator := row.Operands[0]
+			// We flip the order so that a known sequence e.g. 010101xxxxxx
+			// in the permutations is processed as xxxxxx010101 by the binary processing logic.
+			// This means that if we exceed the target value with the first set of operations,
+			// we can easily prune all operations that start with that sequence by skipping the
+			// rest of that block.
+			runPermutation := permutation
+			runPermutation = bits.Reverse64(permutation) >> (65 - (bitsPerOperation * length))
+
+			if debug {
+				fmt.Printf("%v variation %08b (%08b)\n", row, permutation, runPermutation)
+				fmt.Printf("  x = %d\n", rowAccumulator)
+			}
+
+			for field := uint16(1); field < length; field++ {
+				op := Operation(runPermutation & 0b11)
+				if op == add {
+					if debug {
+						fmt.Printf("  x = %d + %d = %d\n", rowAccumulator, row.Operands[field], rowAccumulator+row.Operands[field])
+					}
+					rowAccumulator = rowAccumulator + row.Operands[field]
+				} else if op == mul {
+					if debug {
+						fmt.Printf("  x = %d * %d = %d\n", rowAccumulator, row.Operands[field], rowAccumulator*row.Operands[field])
+					}
+					rowAccumulator = rowAccumulator * row.Operands[field]
+				} else if op == con {
+					if debug {
+						fmt.Printf("  x = %d || %d = ", rowAccumulator, row.Operands[field])
+					}
+					for buf := row.Operands[field]; buf > 0; buf /= 10 {
+						rowAccumulator *= 10
+					}
+					rowAccumulator += row.Operands[field]
+					if debug {
+						fmt.Printf("%d\n", rowAccumulator)
+					}
+				} else {
+					rowAccumulator = math.MaxInt64
+				}
+
+				if rowAccumulator > row.Target {
+					// We're reading the binary string right -> left
+					// But it is the inversion of the outer loop
+					// So we are effectively reading `permutation` left -> right
+					fieldInOriginalPermutation := length - field - 1
+
+					// After `field` fields, we are out of bounds. Any combination of later fields
+					// will also terminate here. So we can prune all those branches.
+					// We achieve this by setting all the remaining bits high.
+					permutation = permutation | ((1 << (bitsPerOperation * fieldInOriginalPermutation)) - 1)
+
+					// When iterating the loop, one more will be added to `permutation`, taking us
+					// out of this branch
+					continue nextPermutation
+				}
+				runPermutation = runPermutation >> bitsPerOperation
+			}
+
+			if rowAccumulator == row.Target {
+				if debug {
+					fmt.Printf("Valid!\n")
+				}
+				validOptions += row.Target
+				continue nextNumber
+			}
+		}
+	}
+
+	if debug {
+		fmt.Printf("Considered %d variations (%.1f%% of possible variations)\n", variationsConsidered, 100*(float64(variationsConsidered)/float64(variationsPossible)))
+	}
+
+	return validOptions, nil
+}
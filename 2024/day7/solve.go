@@ -0,0 +1,8 @@
+package day7
+
+import "tea-cats.co.uk/aoc/runner"
+
+func init() {
+	runner.RegisterParsed(2024, 7, LoadData, Part1, Part2)
+	runner.RegisterVariant(2024, 7, 2, "original", runner.Parsed(LoadData, Part2Original))
+}
@@ -0,0 +1,120 @@
+package day7
+
+import (
+	"context"
+	"tea-cats.co.uk/aoc/2024/generate"
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/runner/runnertest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	runnertest.Examples(t, []runnertest.Example{
+		{Name: "part1", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part1), Want: "3749"},
+		{Name: "part2", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part2), Want: "11387"},
+		{Name: "part2-original", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part2Original), Want: "11387"},
+	})
+}
+
+func TestVariants(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		input := generate.Day7(generate.NewRand(seed), int(seed*50))
+
+		if _, err := runner.Compare(context.Background(), runner.Puzzle{Year: 2024, Day: 7, Part: 2}, input); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+		}
+	}
+}
+
+// solvable tries every way of finishing an equation from acc, without any
+// pruning, using || as well if concat is set.
+func solvable(acc uint64, operands []uint64, target uint64, concat bool) bool {
+	if len(operands) == 0 {
+		return acc == target
+	}
+
+	n, rest := operands[0], operands[1:]
+	return solvable(acc+n, rest, target, concat) ||
+		solvable(acc*n, rest, target, concat) ||
+		concat && solvable(concatenate(acc, n), rest, target, concat)
+}
+
+func concatenate(a, b uint64) uint64 {
+	shift := uint64(10)
+	for shift <= b {
+		shift *= 10
+	}
+	return a*shift + b
+}
+
+// TestPruning checks both parts against trying everything, on short
+// equations with small numbers, where the bounds are tightest, and 0s, which
+// bring values back down.
+func TestPruning(t *testing.T) {
+	rng := generate.NewRand(7)
+	ctx := context.Background()
+
+	for range 20000 {
+		operands := make([]uint64, 1+rng.IntN(5))
+		for i := range operands {
+			operands[i] = rng.Uint64N([]uint64{3, 12, 120}[rng.IntN(3)])
+		}
+
+		// Half the targets can be made with + and *, or ||, and the rest
+		// are near misses.
+		target := operands[0]
+		for _, n := range operands[1:] {
+			switch rng.IntN(3) {
+			case 0:
+				target += n
+			case 1:
+				target *= n
+			default:
+				target = concatenate(target, n)
+			}
+		}
+		if rng.IntN(2) == 0 {
+			target += rng.Uint64N(3) - 1
+		}
+		input := Input{Requests: []Request{{Target: target, Operands: operands}}}
+
+		for part, run := range []func(context.Context, Input) (any, error){Part1, Part2} {
+			want := uint64(0)
+			if solvable(operands[0], operands[1:], input.Requests[0].Target, part == 1) {
+				want = input.Requests[0].Target
+			}
+
+			got, err := run(ctx, input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("part %d of %v: got %v, want %d", part+1, input.Requests[0], got, want)
+			}
+		}
+	}
+}
+
+// TestOverflow checks that a product which only matches the target once it
+// has wrapped around is not taken as a solution, but one which is multiplied
+// by 0 afterwards still counts.
+func TestOverflow(t *testing.T) {
+	input := Input{Requests: []Request{
+		// (2^32+1)^2 wraps around to 2^33+1.
+		{Target: 1<<33 + 1, Operands: []uint64{1<<32 + 1, 1<<32 + 1}},
+		// 1 || 10^19 only matches if 10^20 wraps around.
+		{Target: 17766279631452241920, Operands: []uint64{1, 10000000000000000000}},
+		// 2^40 * 2^40 * 0 + 5
+		{Target: 5, Operands: []uint64{1 << 40, 1 << 40, 0, 5}},
+	}}
+
+	for part, run := range []func(context.Context, Input) (any, error){Part1, Part2} {
+		got, err := run(context.Background(), input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != uint64(5) {
+			t.Errorf("part %d: got %v, want 5", part+1, got)
+		}
+	}
+}
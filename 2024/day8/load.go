@@ -2,7 +2,6 @@ package day8
 
 import (
 	"fmt"
-	"os"
 	"strconv"
 	"tea-cats.co.uk/aoc/2024"
 	"time"
@@ -17,7 +16,7 @@ const Height = 50
 
 func LoadData() [][]AntennaeFrequency {
 	defer utils.TimeTrack(time.Now(), "loadData")
-	dataFile, err := os.Open("2024/input-8.txt")
+	dataFile, err := utils.OpenInput(8)
 
 	if err != nil {
 		panic(err)
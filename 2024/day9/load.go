@@ -0,0 +1,70 @@
+package day9
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	utils "tea-cats.co.uk/aoc/2024"
+	"time"
+)
+
+const debug = false
+
+// Input is the disk map: even entries are the sizes of files, odd entries
+// the free space after them.
+type Input struct {
+	Disk []int
+}
+
+func LoadData(raw []byte) (Input, error) {
+	defer utils.TimeTrack(time.Now(), "loadData")
+
+	input := Input{Disk: make([]int, 0, len(raw))}
+
+	for _, c := range raw {
+		if c == '\n' || c == '\r' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return Input{}, fmt.Errorf("invalid disk map entry %q", c)
+		}
+		input.Disk = append(input.Disk, int(c-'0'))
+	}
+
+	return input, nil
+}
+
+// disk returns a copy of the disk map that a part can modify. The free space
+// either side of an empty file is joined up into the space before it, with
+// none after, as the parts expect each gap between files to be one entry.
+func (input Input) disk() []int {
+	disk := append([]int(nil), input.Disk...)
+
+	// Working backwards carries the space past a run of empty files.
+	for i := (len(disk) - 2) &^ 1; i >= 2; i -= 2 {
+		if disk[i] == 0 {
+			disk[i-1] += disk[i+1]
+			disk[i+1] = 0
+		}
+	}
+
+	return disk
+}
+
+func updateChecksum(checksum *uint64, currentBlock *uint64, fileId uint64, blocks int) {
+	// Sum of ints is n(n+1)/2. We want end-start of that.
+	// [(start+len)(start+len+1) - (start)(start + 1)]/2
+	// [(s^2 + sl + s + l^2 + sl + l) - (s^2 + s)]/2
+	// [(      sl     + l^2 + sl + l)]/2
+	// (l^2 + 2sl + l)/2
+	// l(l + 2s + 1)/2
+	//
+	// But, something-something (start+len-1) so we end up with an off by two error.
+	if debug {
+		fmt.Printf("%s", strings.Repeat(strconv.FormatUint(fileId%10, 10), blocks))
+	}
+
+	blocksUint := uint64(blocks)
+	*checksum += fileId * blocksUint * ((*currentBlock << 1) + blocksUint - 1) / 2
+	*currentBlock += blocksUint
+}
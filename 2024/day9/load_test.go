@@ -0,0 +1,65 @@
+package day9
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"testing"
+)
+
+// FuzzLoadData checks that the loader keeps every digit of the disk map and
+// nothing else, and that the ways of compacting files agree on short maps.
+func FuzzLoadData(f *testing.F) {
+	log.SetOutput(io.Discard)
+
+	raw, err := os.ReadFile("testdata/example-1.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(raw)
+	f.Add([]byte("0"))
+	f.Add([]byte("10101\n"))
+	f.Add([]byte("90909090909\r\n"))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		input, err := LoadData(raw)
+		if err != nil {
+			return
+		}
+
+		digits := 0
+		for _, c := range raw {
+			if c >= '0' && c <= '9' {
+				digits++
+			}
+		}
+		if len(input.Disk) != digits {
+			t.Fatalf("kept %d of %d digits", len(input.Disk), digits)
+		}
+		if len(input.Disk) > 100 {
+			return
+		}
+
+		ctx := context.Background()
+		if _, err := Part1(ctx, input); err != nil {
+			t.Fatal(err)
+		}
+		want, err := Part2(ctx, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, part := range map[string]func(context.Context, Input) (any, error){
+			"original":  Part2Original,
+			"intervals": Part2Intervals,
+		} {
+			got, err := part(ctx, input)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if got != want {
+				t.Errorf("%s gives %v, part 2 gives %v", name, got, want)
+			}
+		}
+	})
+}
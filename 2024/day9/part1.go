@@ -0,0 +1,57 @@
+package day9
+
+import (
+	"context"
+	"fmt"
+)
+
+// Part1 compacts the disk by fragmenting files from the end into the gaps
+// at the start.
+func Part1(ctx context.Context, input Input) (any, error) {
+	buffer := input.disk()
+	if len(buffer) == 0 {
+		return uint64(0), nil
+	}
+
+	checksum := uint64(0)
+
+	var currentWriteIndex, currentReadIndex uint64
+
+	// Find the last file in the input (has to be an even index)
+	currentReadIndex = uint64(len(buffer)-1) & ^uint64(1)
+
+	// Counter for the number of blocks we have put back on the disk
+	currentDiskBlock := uint64(0)
+
+	for currentWriteIndex = 0; currentWriteIndex <= currentReadIndex; currentWriteIndex++ {
+		// Even entries represent a file, which we will not move
+		if currentWriteIndex%2 == 0 {
+			updateChecksum(&checksum, &currentDiskBlock, currentWriteIndex>>1, buffer[currentWriteIndex])
+			continue
+		}
+
+		// Odd entries are spaces we are fragmenting files into
+		for spaceLength := buffer[currentWriteIndex]; spaceLength > 0 && currentReadIndex > currentWriteIndex; currentReadIndex -= 2 {
+			fileLength := buffer[currentReadIndex]
+
+			// Fragment the file if needed
+			writeLength := min(fileLength, spaceLength)
+
+			// Write this chunk
+			updateChecksum(&checksum, &currentDiskBlock, currentReadIndex>>1, writeLength)
+			spaceLength -= writeLength
+
+			// Keep state of the partially fragmented file
+			if fileLength > writeLength {
+				buffer[currentReadIndex] -= writeLength
+				break
+			}
+		}
+	}
+
+	if debug {
+		fmt.Printf("\nFinal locations: write=%d, reader=%d, block=%d\n", currentWriteIndex, currentReadIndex, currentDiskBlock)
+	}
+
+	return checksum, nil
+}
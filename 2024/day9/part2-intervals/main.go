@@ -0,0 +1,10 @@
+package main
+
+import (
+	_ "tea-cats.co.uk/aoc/2024/day9"
+	"tea-cats.co.uk/aoc/runner"
+)
+
+func main() {
+	runner.MainVariant(2024, 9, 2, "intervals")
+}
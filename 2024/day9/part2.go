@@ -0,0 +1,147 @@
+package day9
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type fileSpec struct {
+	fileId uint64
+	size   int
+}
+
+type priorityQueue struct {
+	sourceBuffer []int
+	readHead     uint64
+	queues       [9][]fileSpec
+}
+
+func newPriorityQueue(buffer []int) *priorityQueue {
+	var queues [9][]fileSpec
+
+	for i := range queues {
+		queues[i] = make([]fileSpec, 0)
+	}
+
+	return &priorityQueue{
+		sourceBuffer: buffer,
+		readHead:     uint64(len(buffer)-1) & ^uint64(1),
+		queues:       queues,
+	}
+}
+
+func (queues *priorityQueue) get(spaceSize int, after uint64) fileSpec {
+	matched := fileSpec{
+		fileId: 0,
+		size:   0,
+	}
+
+	// Find the highest ID file that fits in the space, which can be longer
+	// than any file where gaps have been joined up around empty files.
+	for i := 0; i < min(spaceSize, len(queues.queues)); i++ {
+		// Skip empty queues
+		if len(queues.queues[i]) == 0 {
+			continue
+		}
+
+		potentialFileId := queues.queues[i][0].fileId
+		// If the first item in a queue is from before our current position,
+		// we can drop that entire queue.
+		if potentialFileId < (after >> 1) {
+			queues.queues[i] = []fileSpec{}
+		} else if potentialFileId > matched.fileId {
+			// Otherwise, keep it if it's file from further on in the disk
+			matched = fileSpec{fileId: potentialFileId, size: i + 1}
+		}
+	}
+
+	// If the matched file is from further on in the disk than we have de-fragged...
+	if matched.fileId > (after >> 1) {
+		// Mark the file as moved
+		queues.sourceBuffer[matched.fileId<<1] = -matched.size
+		// Remove it from the queue
+		queues.queues[matched.size-1] = queues.queues[matched.size-1][1:]
+		// And give it back to the moving function
+		return matched
+	}
+
+	// Otherwise, scan the filesystem from right to left, filling queues
+	// until we either reach the left-to-right process or a file we can move.
+	for ; queues.readHead > after; queues.readHead -= 2 {
+		size := queues.sourceBuffer[queues.readHead]
+		matched = fileSpec{fileId: queues.readHead >> 1, size: size}
+
+		if size <= spaceSize {
+			queues.sourceBuffer[queues.readHead] = -queues.sourceBuffer[queues.readHead]
+			queues.readHead -= 2
+			return matched
+		}
+
+		queues.queues[size-1] = append(queues.queues[size-1], matched)
+	}
+
+	return fileSpec{fileId: 0}
+}
+
+// Part2 moves whole files into the left-most space they fit in, using a
+// queue per file size so that each file is only looked at once.
+func Part2(ctx context.Context, input Input) (any, error) {
+	buffer := input.disk()
+	if len(buffer) == 0 {
+		return uint64(0), nil
+	}
+
+	checksum := uint64(0)
+
+	queue := newPriorityQueue(buffer)
+	initialReadIndex := queue.readHead
+
+	// Counter for the number of blocks we have put back on the disk
+	currentDiskBlock := uint64(0)
+
+	for scanPosition := uint64(0); scanPosition <= initialReadIndex; scanPosition++ {
+		// Even entries represent a file
+		if scanPosition%2 == 0 {
+			fileLength := buffer[scanPosition]
+
+			// Skip files that have been moved in the defragmentation process
+			if fileLength < 0 {
+				if debug {
+					fmt.Printf("%s", strings.Repeat("x", -fileLength))
+				}
+				currentDiskBlock += uint64(-fileLength)
+				continue
+			}
+
+			// Write out the file in the same position as it originally was
+			updateChecksum(&checksum, &currentDiskBlock, scanPosition>>1, fileLength)
+		} else {
+			spaceLength := buffer[scanPosition]
+
+			for spaceLength > 0 {
+				fileToMove := queue.get(spaceLength, scanPosition)
+
+				if fileToMove.fileId == 0 {
+					break
+				}
+
+				// Write out the relocated file
+				updateChecksum(&checksum, &currentDiskBlock, fileToMove.fileId, fileToMove.size)
+				// Update our remaining free space
+				spaceLength -= fileToMove.size
+			}
+
+			if debug {
+				fmt.Printf("%s", strings.Repeat(".", spaceLength))
+			}
+			currentDiskBlock += uint64(spaceLength)
+		}
+	}
+
+	if debug {
+		fmt.Printf("\nFinal locations: block=%d\n", currentDiskBlock)
+	}
+
+	return checksum, nil
+}
@@ -0,0 +1,61 @@
+package day9
+
+import (
+	"context"
+	"fmt"
+	utils "tea-cats.co.uk/aoc/2024"
+)
+
+type file struct {
+	fileId uint64
+	start  int
+	size   int
+}
+
+// Part2Intervals is part 2 on top of utils.IntervalSet, tracking the free
+// space as intervals rather than rescanning the disk map.
+func Part2Intervals(ctx context.Context, input Input) (any, error) {
+	files := make([]file, 0, len(input.Disk)/2+1)
+	free := utils.IntervalSet{}
+	position := 0
+
+	// Even entries represent a file, odd entries the free space after it
+	for i, size := range input.Disk {
+		if i%2 == 0 {
+			files = append(files, file{fileId: uint64(i >> 1), start: position, size: size})
+		} else {
+			free.Insert(position, position+size)
+		}
+		position += size
+	}
+
+	// Move each file, highest ID first, into the left-most space it fits in
+	for i := len(files) - 1; i >= 0; i-- {
+		space, ok := free.FirstFit(files[i].size)
+
+		if !ok || space.Start >= files[i].start {
+			continue
+		}
+
+		if debug {
+			fmt.Printf("Moving file %d from %d to %d\n", files[i].fileId, files[i].start, space.Start)
+		}
+
+		free.Remove(space.Start, space.Start+files[i].size)
+		free.Insert(files[i].start, files[i].start+files[i].size)
+		files[i].start = space.Start
+	}
+
+	checksum := uint64(0)
+	for _, f := range files {
+		// Sum of the block positions is l(l + 2s - 1)/2
+		blocks := uint64(f.size)
+		checksum += f.fileId * blocks * (uint64(f.start<<1) + blocks - 1) / 2
+	}
+
+	if debug {
+		fmt.Printf("Free blocks: %d in %d spaces\n", free.Len(), len(free.Intervals()))
+	}
+
+	return checksum, nil
+}
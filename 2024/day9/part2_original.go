@@ -0,0 +1,74 @@
+package day9
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Part2Original is the first version of part 2, which rescans the disk from
+// the end for every space. It is kept to check Part2 against.
+func Part2Original(ctx context.Context, input Input) (any, error) {
+	buffer := input.disk()
+	if len(buffer) == 0 {
+		return uint64(0), nil
+	}
+
+	checksum := uint64(0)
+
+	var currentWriteIndex, currentReadIndex uint64
+
+	initialReadIndex := uint64(len(buffer)-1) & ^uint64(1)
+	currentReadIndex = initialReadIndex
+
+	// Counter for the number of blocks we have put back on the disk
+	currentDiskBlock := uint64(0)
+
+	for currentWriteIndex = 0; currentWriteIndex <= initialReadIndex; currentWriteIndex++ {
+		// Even entries represent a file
+		if currentWriteIndex%2 == 0 {
+			fileLength := buffer[currentWriteIndex]
+
+			// Skip files that have been moved in the defragmentation process
+			if fileLength < 0 {
+				if debug {
+					fmt.Printf("%s", strings.Repeat("x", -fileLength))
+				}
+				currentDiskBlock += uint64(-fileLength)
+				continue
+			}
+
+			updateChecksum(&checksum, &currentDiskBlock, currentWriteIndex>>1, fileLength)
+		} else {
+			spaceLength := buffer[currentWriteIndex]
+			currentReadIndex = initialReadIndex
+
+			for ; spaceLength > 0 && currentReadIndex > currentWriteIndex; currentReadIndex -= 2 {
+				fileLength := buffer[currentReadIndex]
+
+				// Do not move files that have already been moved, or won't fit in this space.
+				if fileLength < 0 || fileLength > spaceLength {
+					continue
+				}
+
+				// Write out the relocated file
+				updateChecksum(&checksum, &currentDiskBlock, currentReadIndex>>1, fileLength)
+
+				// Update our remaining free space, and mark the file as moved by making the space negative
+				spaceLength -= fileLength
+				buffer[currentReadIndex] = -fileLength
+			}
+
+			if debug {
+				fmt.Printf("%s", strings.Repeat(".", spaceLength))
+			}
+			currentDiskBlock += uint64(spaceLength)
+		}
+	}
+
+	if debug {
+		fmt.Printf("\nFinal locations: write=%d, reader=%d, block=%d\n", currentWriteIndex, currentReadIndex, currentDiskBlock)
+	}
+
+	return checksum, nil
+}
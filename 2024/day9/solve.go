@@ -0,0 +1,9 @@
+package day9
+
+import "tea-cats.co.uk/aoc/runner"
+
+func init() {
+	runner.RegisterParsed(2024, 9, LoadData, Part1, Part2)
+	runner.RegisterVariant(2024, 9, 2, "original", runner.Parsed(LoadData, Part2Original))
+	runner.RegisterVariant(2024, 9, 2, "intervals", runner.Parsed(LoadData, Part2Intervals))
+}
@@ -0,0 +1,67 @@
+package day9
+
+import (
+	"context"
+	"tea-cats.co.uk/aoc/2024/generate"
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/runner/runnertest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	runnertest.Examples(t, []runnertest.Example{
+		{Name: "part1", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part1), Want: "1928"},
+		{Name: "part2", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part2), Want: "2858"},
+		{Name: "part2-original", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part2Original), Want: "2858"},
+		{Name: "part2-intervals", File: "testdata/example-1.txt", Solve: runner.Parsed(LoadData, Part2Intervals), Want: "2858"},
+	})
+}
+
+func TestVariants(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		input := generate.Day9(generate.NewRand(seed), int(seed*seed*10))
+
+		if _, err := runner.Compare(context.Background(), runner.Puzzle{Year: 2024, Day: 9, Part: 2}, input); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+		}
+	}
+}
+
+// TestEmptyFiles checks that files of no blocks do not split up the space
+// around them.
+func TestEmptyFiles(t *testing.T) {
+	tests := []struct {
+		disk string
+		want uint64
+	}{
+		// The gaps of 2 and 7 around file 1 join up, so 22 fits next to 3.
+		{"0207201", 3*0 + 2*1 + 2*2},
+		// A run of empty files leaves one gap of 3, which 333 fits in.
+		{"1101013", 3 * (1 + 2 + 3)},
+		// Joined-up gaps can be longer than any file.
+		{"1909091", 3 * 1},
+		// An empty file at the end, which has no space after it.
+		{"12110", 1 * 1},
+	}
+
+	for _, test := range tests {
+		input, err := LoadData([]byte(test.disk))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for name, part := range map[string]func(context.Context, Input) (any, error){
+			"part2":     Part2,
+			"original":  Part2Original,
+			"intervals": Part2Intervals,
+		} {
+			got, err := part(context.Background(), input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("%s of %s: got %v, want %d", name, test.disk, got, test.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package generate
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// Day1 generates pairs of five-digit location IDs. Right hand IDs are drawn
+// from the left list often enough that the similarity score is non-zero.
+func Day1(rng *rand.Rand, lines int) []byte {
+	left := make([]int, lines)
+	for i := range left {
+		left[i] = between(rng, 10000, 99999)
+	}
+
+	var out strings.Builder
+	for _, l := range left {
+		r := between(rng, 10000, 99999)
+		if rng.IntN(4) == 0 {
+			r = left[rng.IntN(lines)]
+		}
+		fmt.Fprintf(&out, "%d   %d\n", l, r)
+	}
+
+	return []byte(out.String())
+}
@@ -0,0 +1,43 @@
+package generate
+
+import (
+	"image"
+	"math/rand/v2"
+	"slices"
+)
+
+// Day10 generates a square topographic map. Hiking trails (which climb from
+// 0 to 9 one step at a time) are laid over random terrain, so they cross and
+// share sections like the real maps.
+func Day10(rng *rand.Rand, size int) []byte {
+	g := newGrid(size, size, '.')
+
+	for y := range g {
+		for x := range g[y] {
+			g[y][x] = byte('0' + rng.IntN(10))
+		}
+	}
+
+	for range size * size / 25 {
+		p := image.Point{X: rng.IntN(size), Y: rng.IntN(size)}
+		trail := []image.Point{p}
+
+		for len(trail) < 10 {
+			next := trail[len(trail)-1].Add(directions[rng.IntN(len(directions))])
+			if !g.in(next) || slices.Contains(trail, next) {
+				break
+			}
+			trail = append(trail, next)
+		}
+
+		if len(trail) < 10 {
+			continue
+		}
+
+		for height, p := range trail {
+			g.set(p, byte('0'+height))
+		}
+	}
+
+	return g.bytes()
+}
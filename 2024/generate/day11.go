@@ -0,0 +1,18 @@
+package generate
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Day11 generates a line of engraved stones, from single digits up to seven
+// digit numbers.
+func Day11(rng *rand.Rand, stones int) []byte {
+	values := make([]string, stones)
+	for i := range values {
+		values[i] = strconv.Itoa(rng.IntN(10_000_000) >> rng.IntN(20))
+	}
+
+	return []byte(strings.Join(values, " ") + "\n")
+}
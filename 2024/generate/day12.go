@@ -0,0 +1,46 @@
+package generate
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// Day12 generates a square garden. Regions are grown outwards from random
+// seed plots in a random order, so they have ragged edges and the same plant
+// often appears in several separate regions.
+func Day12(rng *rand.Rand, size int) []byte {
+	g := newGrid(size, size, '.')
+	frontier := make([]image.Point, 0)
+
+	for range max(1, size*size/70) {
+		p := image.Point{X: rng.IntN(size), Y: rng.IntN(size)}
+		g.set(p, byte('A'+rng.IntN(26)))
+		frontier = append(frontier, p)
+	}
+
+	for len(frontier) > 0 {
+		i := rng.IntN(len(frontier))
+		p := frontier[i]
+		next := p.Add(directions[rng.IntN(len(directions))])
+
+		if g.in(next) && g.at(next) == '.' {
+			g.set(next, g.at(p))
+			frontier = append(frontier, next)
+			continue
+		}
+
+		// Retire plots once they have no unclaimed neighbours.
+		full := true
+		for _, d := range directions {
+			if n := p.Add(d); g.in(n) && g.at(n) == '.' {
+				full = false
+			}
+		}
+		if full {
+			frontier[i] = frontier[len(frontier)-1]
+			frontier = frontier[:len(frontier)-1]
+		}
+	}
+
+	return g.bytes()
+}
@@ -0,0 +1,32 @@
+package generate
+
+import (
+	"fmt"
+	"image"
+	"math/rand/v2"
+	"strings"
+)
+
+// Day13 generates claw machines. About a third have a prize which can be
+// reached with at most a hundred presses of each button; the rest have the
+// prize somewhere at random, which is almost never reachable.
+func Day13(rng *rand.Rand, machines int) []byte {
+	var out strings.Builder
+
+	for i := range machines {
+		a := image.Point{X: between(rng, 10, 99), Y: between(rng, 10, 99)}
+		b := image.Point{X: between(rng, 10, 99), Y: between(rng, 10, 99)}
+		prize := image.Point{X: between(rng, 1000, 20000), Y: between(rng, 1000, 20000)}
+
+		if rng.IntN(3) == 0 {
+			prize = a.Mul(between(rng, 0, 100)).Add(b.Mul(between(rng, 0, 100)))
+		}
+
+		if i > 0 {
+			out.WriteByte('\n')
+		}
+		fmt.Fprintf(&out, "Button A: X+%d, Y+%d\nButton B: X+%d, Y+%d\nPrize: X=%d, Y=%d\n", a.X, a.Y, b.X, b.Y, prize.X, prize.Y)
+	}
+
+	return []byte(out.String())
+}
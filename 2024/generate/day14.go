@@ -0,0 +1,168 @@
+package generate
+
+import (
+	"fmt"
+	"image"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Day14 generates a swarm of robots on the 101 by 103 bathroom floor. At one
+// random second the robots all stand on different tiles, with as many of
+// them as there are to spare drawing a framed tree; at every other second at
+// least two robots share a tile. That is arranged by day14Colliders, so there
+// are always at least enough robots for them.
+func Day14(rng *rand.Rand, robots int) []byte {
+	floor := image.Rect(0, 0, 101, 103)
+	period := floor.Dx() * floor.Dy()
+	second := rng.IntN(period)
+
+	// Everything is placed as it is at the chosen second.
+	picture := day14Picture(floor)
+	positions, velocities := day14Colliders(rng, floor, picture)
+	robots = max(robots, len(positions))
+
+	taken := make(map[image.Point]struct{}, robots)
+	for _, p := range positions {
+		taken[p] = struct{}{}
+	}
+	place := func(p image.Point) {
+		if _, ok := taken[p]; ok || len(positions) == robots {
+			return
+		}
+		taken[p] = struct{}{}
+		positions = append(positions, p)
+		velocities = append(velocities, image.Point{X: between(rng, -100, 100), Y: between(rng, -102, 102)})
+	}
+
+	for _, p := range picture {
+		place(p)
+	}
+	for len(positions) < robots && len(taken) < period {
+		place(image.Point{X: rng.IntN(floor.Dx()), Y: rng.IntN(floor.Dy())})
+	}
+
+	// Wind each robot back from where it is at the chosen second.
+	for i, p := range positions {
+		positions[i] = p.Sub(velocities[i].Mul(second)).Mod(floor)
+	}
+
+	rng.Shuffle(len(positions), func(i, j int) {
+		positions[i], positions[j] = positions[j], positions[i]
+		velocities[i], velocities[j] = velocities[j], velocities[i]
+	})
+
+	var out strings.Builder
+	for i, p := range positions {
+		fmt.Fprintf(&out, "p=%d,%d v=%d,%d\n", p.X, p.Y, velocities[i].X, velocities[i].Y)
+	}
+	return []byte(out.String())
+}
+
+// day14Colliders places robots, as they are at second zero, so that they are
+// all on different tiles then but two of them share a tile at every other
+// second of the period.
+//
+// A column of robots all move sideways together, half of them at one speed
+// down the column and half one faster. A slow robot at offset a and a fast
+// one at offset b meet after a-b seconds, modulo the height, so the offsets
+// are chosen to make a-b every residue but zero: that covers every second
+// not a multiple of the height. A row does the same across the width, which
+// covers every multiple of the height except the multiples of the whole
+// period. The column and the row keep clear of the picture and each other.
+func day14Colliders(rng *rand.Rand, floor image.Rectangle, picture []image.Point) (positions, velocities []image.Point) {
+	columns := make(map[int]bool)
+	rows := make(map[int]bool)
+	for _, p := range picture {
+		columns[p.X] = true
+		rows[p.Y] = true
+	}
+
+	slowY, fastY := day14Offsets(floor.Dy())
+	slowX, fastX := day14Offsets(floor.Dx())
+
+	column := rng.IntN(floor.Dx())
+	for columns[column] {
+		column = rng.IntN(floor.Dx())
+	}
+	row := rng.IntN(floor.Dy())
+	for rows[row] {
+		row = rng.IntN(floor.Dy())
+	}
+
+	// The row must not cross the column.
+	across := rng.IntN(floor.Dx())
+	for slices.ContainsFunc(append(slowX, fastX...), func(x int) bool { return (across+x)%floor.Dx() == column }) {
+		across = rng.IntN(floor.Dx())
+	}
+	down := rng.IntN(floor.Dy())
+
+	vx, vy := between(rng, -100, 100), between(rng, -102, 101)
+	for _, y := range slowY {
+		positions = append(positions, image.Pt(column, (down+y)%floor.Dy()))
+		velocities = append(velocities, image.Pt(vx, vy))
+	}
+	for _, y := range fastY {
+		positions = append(positions, image.Pt(column, (down+y)%floor.Dy()))
+		velocities = append(velocities, image.Pt(vx, vy+1))
+	}
+
+	vx, vy = between(rng, -100, 99), between(rng, -102, 102)
+	for _, x := range slowX {
+		positions = append(positions, image.Pt((across+x)%floor.Dx(), row))
+		velocities = append(velocities, image.Pt(vx, vy))
+	}
+	for _, x := range fastX {
+		positions = append(positions, image.Pt((across+x)%floor.Dx(), row))
+		velocities = append(velocities, image.Pt(vx+1, vy))
+	}
+
+	return positions, velocities
+}
+
+// day14Offsets splits size-1 into slow times fast, as evenly as it can, and
+// returns offsets where slow[i] - fast[j] takes every value from 1 to size-1
+// exactly once, modulo size.
+func day14Offsets(size int) (slow, fast []int) {
+	count := 1
+	for n := 2; n*n <= size-1; n++ {
+		if (size-1)%n == 0 {
+			count = n
+		}
+	}
+
+	for i := range count {
+		slow = append(slow, i)
+	}
+	for j := range (size - 1) / count {
+		fast = append(fast, size-1-count*j)
+	}
+	return slow, fast
+}
+
+// day14Picture returns the tiles of a tree inside a frame, roughly centred.
+func day14Picture(floor image.Rectangle) []image.Point {
+	frame := image.Rect(0, 0, 31, 33).Add(floor.Size().Sub(image.Pt(31, 33)).Div(2))
+	points := make([]image.Point, 0)
+
+	for x := frame.Min.X; x < frame.Max.X; x++ {
+		points = append(points, image.Pt(x, frame.Min.Y), image.Pt(x, frame.Max.Y-1))
+	}
+	for y := frame.Min.Y + 1; y < frame.Max.Y-1; y++ {
+		points = append(points, image.Pt(frame.Min.X, y), image.Pt(frame.Max.X-1, y))
+	}
+
+	centre := frame.Min.X + frame.Dx()/2
+	for row := 0; row < frame.Dy()-6; row++ {
+		width := row/2%7 + row/8
+		for x := centre - width; x <= centre+width; x++ {
+			points = append(points, image.Pt(x, frame.Min.Y+2+row))
+		}
+	}
+	for y := frame.Max.Y - 4; y < frame.Max.Y-1; y++ {
+		points = append(points, image.Pt(centre, y))
+	}
+
+	return points
+}
@@ -0,0 +1,42 @@
+package generate
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// Day15 generates a walled square warehouse scattered with boxes and the odd
+// wall, the robot near the middle, and eight moves for every tile in lines
+// of a thousand.
+func Day15(rng *rand.Rand, size int) []byte {
+	g := newGrid(size, size, '.')
+
+	for y := range g {
+		for x := range g[y] {
+			switch {
+			case x == 0 || y == 0 || x == size-1 || y == size-1:
+				g[y][x] = '#'
+			case rng.IntN(12) == 0:
+				g[y][x] = '#'
+			case rng.IntN(3) == 0:
+				g[y][x] = 'O'
+			}
+		}
+	}
+
+	g.set(image.Point{X: size / 2, Y: size / 2}, '@')
+
+	const moves = "^>v<"
+	out := g.bytes()
+	out = append(out, '\n')
+
+	count := size * size * 8
+	for i := range count {
+		out = append(out, moves[rng.IntN(len(moves))])
+		if i%1000 == 999 || i == count-1 {
+			out = append(out, '\n')
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,30 @@
+package generate
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// Day16 generates a square maze with the start in the bottom left corner and
+// the end in the top right. The maze starts out perfect, so there is always
+// a route, then has walls knocked out to give alternative routes of similar
+// cost. Even sizes are rounded up, and sizes below 5 raised to 5 to keep the
+// start and end apart.
+func Day16(rng *rand.Rand, size int) []byte {
+	size = max(size, 5) | 1
+	maze := perfectMaze(rng, size, size)
+
+	for range size * size / 40 {
+		p := image.Point{X: between(rng, 1, size-2), Y: between(rng, 1, size-2)}
+
+		// Only walls between two open cells are removed.
+		if (p.X+p.Y)%2 == 1 {
+			maze.set(p, '.')
+		}
+	}
+
+	maze.set(image.Point{X: 1, Y: size - 2}, 'S')
+	maze.set(image.Point{X: size - 2, Y: 1}, 'E')
+
+	return maze.bytes()
+}
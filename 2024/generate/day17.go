@@ -0,0 +1,101 @@
+package generate
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// Day17 generates a program with the same shape as the real ones: each pass
+// of the loop hashes the low bits of A with a right shift of A, outputs three
+// bits of B, and shifts A right by three. Part 2 relies on that shape. Most
+// hashes cannot output the program itself, so they are re-rolled until one
+// can, and part 2 always has an answer. A is a random number with the given
+// count of octal digits.
+func Day17(rng *rand.Rand, digits int) []byte {
+	var program [][2]int
+	for program == nil || !hasQuine(program) {
+		middle := [][2]int{{0, 3}, {4, rng.IntN(8)}, {1, rng.IntN(8)}}
+		rng.Shuffle(len(middle), func(i, j int) { middle[i], middle[j] = middle[j], middle[i] })
+
+		program = [][2]int{{2, 4}, {1, rng.IntN(8)}, {7, 5}}
+		program = append(program, middle...)
+		program = append(program, [2]int{5, 5}, [2]int{3, 0})
+	}
+
+	words := make([]string, 0, len(program)*2)
+	for _, instruction := range program {
+		words = append(words, fmt.Sprint(instruction[0]), fmt.Sprint(instruction[1]))
+	}
+
+	a := uint64(between(rng, 1, 7))
+	for range digits - 1 {
+		a = a<<3 | rng.Uint64N(8)
+	}
+
+	return []byte(fmt.Sprintf("Register A: %d\nRegister B: 0\nRegister C: 0\n\nProgram: %s\n", a, strings.Join(words, ",")))
+}
+
+// hasQuine reports whether some A makes the program output itself. Each pass
+// only sees A, which loses three bits a pass, so A is built up three bits at
+// a time from the last output back to the first.
+func hasQuine(program [][2]int) bool {
+	code := make([]uint64, 0, len(program)*2)
+	for _, instruction := range program {
+		code = append(code, uint64(instruction[0]), uint64(instruction[1]))
+	}
+
+	var search func(a uint64, i int) bool
+	search = func(a uint64, i int) bool {
+		if i < 0 {
+			return true
+		}
+		for digit := range uint64(8) {
+			// A leading zero would leave the program a pass short.
+			next := a<<3 | digit
+			if next != 0 && firstOutput(program, next) == code[i] && search(next, i-1) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return search(0, len(code)-1)
+}
+
+// firstOutput runs the program from A until it outputs something.
+func firstOutput(program [][2]int, a uint64) uint64 {
+	var b, c uint64
+	combo := func(operand int) uint64 {
+		switch operand {
+		case 4:
+			return a
+		case 5:
+			return b
+		case 6:
+			return c
+		}
+		return uint64(operand)
+	}
+
+	for _, instruction := range program {
+		switch operand := instruction[1]; instruction[0] {
+		case 0:
+			a >>= combo(operand)
+		case 1:
+			b ^= uint64(operand)
+		case 2:
+			b = combo(operand) % 8
+		case 4:
+			b ^= c
+		case 5:
+			return combo(operand) % 8
+		case 6:
+			b = a >> combo(operand)
+		case 7:
+			c = a >> combo(operand)
+		}
+	}
+
+	panic("the program has no output")
+}
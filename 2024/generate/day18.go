@@ -0,0 +1,57 @@
+package generate
+
+import (
+	"fmt"
+	"image"
+	"math/rand/v2"
+	"strings"
+)
+
+// Day18 generates the bytes falling into a square memory space. The first
+// kilobyte (what part 1 simulates) stays off a winding route from the top
+// left to the bottom right corner; after that every other location falls in
+// a random order, so the exit is always cut off eventually. Even sizes are
+// rounded up, and a size of 1 raised to 3, as there would be nothing to
+// fall.
+func Day18(rng *rand.Rand, size int) []byte {
+	size = max(size, 3) | 1
+
+	// A maze two larger than the memory space has open cells on every even
+	// coordinate of the space, including both corners.
+	maze := perfectMaze(rng, size+2, size+2)
+	reserved := make(map[image.Point]struct{})
+
+	for _, p := range route(maze, image.Pt(1, 1), image.Pt(size, size), func(c byte) bool { return c == '.' }) {
+		reserved[p.Sub(image.Pt(1, 1))] = struct{}{}
+	}
+
+	early := make([]image.Point, 0, size*size)
+	late := make([]image.Point, 0, size*size)
+
+	for y := range size {
+		for x := range size {
+			p := image.Pt(x, y)
+			if p == image.Pt(0, 0) || p == image.Pt(size-1, size-1) {
+				continue
+			}
+			if _, ok := reserved[p]; ok {
+				late = append(late, p)
+			} else {
+				early = append(early, p)
+			}
+		}
+	}
+
+	rng.Shuffle(len(early), func(i, j int) { early[i], early[j] = early[j], early[i] })
+
+	first := min(1024, len(early))
+	late = append(late, early[first:]...)
+	rng.Shuffle(len(late), func(i, j int) { late[i], late[j] = late[j], late[i] })
+
+	var out strings.Builder
+	for _, p := range append(early[:first], late...) {
+		fmt.Fprintf(&out, "%d,%d\n", p.X, p.Y)
+	}
+
+	return []byte(out.String())
+}
@@ -0,0 +1,56 @@
+package generate
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// Day19 generates a few hundred towel patterns of the five colours and the
+// given number of designs. One colour has no single-stripe towel, so the
+// designs with random stripes are often impossible; the rest are built from
+// the towels and are always possible.
+func Day19(rng *rand.Rand, designs int) []byte {
+	const colours = "wubrg"
+	missing := colours[rng.IntN(len(colours))]
+
+	stripes := func(length int) string {
+		pattern := make([]byte, length)
+		for i := range pattern {
+			pattern[i] = colours[rng.IntN(len(colours))]
+		}
+		return string(pattern)
+	}
+
+	seen := make(map[string]struct{})
+	towels := make([]string, 0, 450)
+
+	for len(towels) < 450 {
+		towel := stripes(between(rng, 1, 8))
+		if _, ok := seen[towel]; ok || towel == string(missing) {
+			continue
+		}
+		seen[towel] = struct{}{}
+		towels = append(towels, towel)
+	}
+
+	var out strings.Builder
+	out.WriteString(strings.Join(towels, ", "))
+	out.WriteString("\n\n")
+
+	for range designs {
+		length := between(rng, 20, 60)
+
+		if rng.IntN(3) == 0 {
+			out.WriteString(stripes(length))
+		} else {
+			var design strings.Builder
+			for design.Len() < length {
+				design.WriteString(towels[rng.IntN(len(towels))])
+			}
+			out.WriteString(design.String())
+		}
+		out.WriteByte('\n')
+	}
+
+	return []byte(out.String())
+}
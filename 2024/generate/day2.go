@@ -0,0 +1,38 @@
+package generate
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Day2 generates reports of five to eight levels. Most are gently increasing
+// or decreasing, and a share of those get a single bad level so that the
+// problem dampener has something to do.
+func Day2(rng *rand.Rand, lines int) []byte {
+	var out strings.Builder
+
+	for range lines {
+		levels := make([]int, between(rng, 5, 8))
+		direction := 1 - 2*rng.IntN(2)
+		levels[0] = between(rng, 10, 90)
+
+		for i := 1; i < len(levels); i++ {
+			levels[i] = levels[i-1] + direction*between(rng, 1, 3)
+		}
+
+		if rng.IntN(2) == 0 {
+			levels[rng.IntN(len(levels))] += between(rng, -6, 6)
+		}
+
+		for i, level := range levels {
+			if i > 0 {
+				out.WriteByte(' ')
+			}
+			out.WriteString(strconv.Itoa(max(level, 1)))
+		}
+		out.WriteByte('\n')
+	}
+
+	return []byte(out.String())
+}
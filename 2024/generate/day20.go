@@ -0,0 +1,26 @@
+package generate
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// Day20 generates a square racetrack made of a single track with no
+// branches: the route between two corners of a perfect maze, with every
+// other cell turned back into wall. Even sizes are rounded up, and sizes
+// below 5 raised to 5 to keep the start and end apart.
+func Day20(rng *rand.Rand, size int) []byte {
+	size = max(size, 5) | 1
+	maze := perfectMaze(rng, size, size)
+	track := route(maze, image.Pt(1, 1), image.Pt(size-2, size-2), func(c byte) bool { return c == '.' })
+
+	g := newGrid(size, size, '#')
+	for _, p := range track {
+		g.set(p, '.')
+	}
+
+	g.set(track[0], 'S')
+	g.set(track[len(track)-1], 'E')
+
+	return g.bytes()
+}
@@ -0,0 +1,19 @@
+package generate
+
+import (
+	"math/rand/v2"
+	"strconv"
+)
+
+// Day22 generates the initial secret numbers of the buyers, all below the
+// 2^24 modulus the secrets are pruned to.
+func Day22(rng *rand.Rand, buyers int) []byte {
+	out := make([]byte, 0, buyers*9)
+
+	for range buyers {
+		out = strconv.AppendInt(out, int64(between(rng, 1, 1<<24-1)), 10)
+		out = append(out, '\n')
+	}
+
+	return out
+}
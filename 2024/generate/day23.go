@@ -0,0 +1,57 @@
+package generate
+
+import (
+	"cmp"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Day23 generates a network of computers with two-letter names, each linked
+// to about a dozen others at random, with one party of thirteen computers
+// that are all linked to each other. There are at most 676 computers.
+func Day23(rng *rand.Rand, computers int) []byte {
+	const party = 13
+	computers = max(party, min(computers, 26*26))
+
+	computerNames := names(rng, computers, 2, "")
+	links := make(map[[2]int]struct{})
+
+	link := func(a, b int) {
+		if a > b {
+			a, b = b, a
+		}
+		if a != b {
+			links[[2]int{a, b}] = struct{}{}
+		}
+	}
+
+	for a := range party {
+		for b := a + 1; b < party; b++ {
+			link(a, b)
+		}
+	}
+
+	for range computers * 6 {
+		link(rng.IntN(computers), rng.IntN(computers))
+	}
+
+	// Sorted first, so the same seed always gives the same input.
+	pairs := slices.SortedFunc(maps.Keys(links), func(a, b [2]int) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+
+	lines := make([]string, 0, len(links))
+	for _, pair := range pairs {
+		a, b := computerNames[pair[0]], computerNames[pair[1]]
+		if rng.IntN(2) == 0 {
+			a, b = b, a
+		}
+		lines = append(lines, a+"-"+b+"\n")
+	}
+
+	rng.Shuffle(len(lines), func(i, j int) { lines[i], lines[j] = lines[j], lines[i] })
+
+	return []byte(strings.Join(lines, ""))
+}
@@ -0,0 +1,149 @@
+package generate
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// Day24 generates a ripple-carry adder for numbers of the given bit width,
+// with options.Swaps pairs of gate outputs swapped. See Adder.
+func Day24(rng *rand.Rand, bits int, options Options) []byte {
+	return Adder(rng, bits, options.Swaps)
+}
+
+type adderGate struct {
+	left, op, right, out string
+}
+
+// Adder generates a genuine ripple-carry adder of x and y into z, made of a
+// half adder for bit 0 and full adders (two XOR, two AND and an OR gate) for
+// the rest, with the carry out of the top bit as the extra z wire. Then the
+// outputs of swaps pairs of gates are exchanged, each pair within a
+// different bit's adder and never leaving a loop in the circuit. Widths are
+// limited to 99 bits by the two-digit wire names.
+func Adder(rng *rand.Rand, bits int, swaps int) []byte {
+	bits = max(2, min(bits, 99))
+	swaps = min(swaps, bits-1)
+
+	internal := names(rng, 4*bits, 3, "xyz")
+	wire := func() string {
+		w := internal[0]
+		internal = internal[1:]
+		return w
+	}
+	x := func(i int) string { return fmt.Sprintf("x%02d", i) }
+	y := func(i int) string { return fmt.Sprintf("y%02d", i) }
+	z := func(i int) string { return fmt.Sprintf("z%02d", i) }
+
+	// Gates of bit i are gates[i], in the order sum, carry-generate, z,
+	// carry-propagate, carry out; bit 0 only has the first two.
+	gates := make([][]*adderGate, bits)
+	carry := wire()
+	gates[0] = []*adderGate{
+		{x(0), "XOR", y(0), z(0)},
+		{x(0), "AND", y(0), carry},
+	}
+
+	for i := 1; i < bits; i++ {
+		sum, generate, propagate := wire(), wire(), wire()
+		next := wire()
+		if i == bits-1 {
+			next = z(bits)
+		}
+
+		gates[i] = []*adderGate{
+			{x(i), "XOR", y(i), sum},
+			{x(i), "AND", y(i), generate},
+			{sum, "XOR", carry, z(i)},
+			{sum, "AND", carry, propagate},
+			{generate, "OR", propagate, next},
+		}
+		carry = next
+	}
+
+	for _, i := range rng.Perm(bits - 1)[:swaps] {
+		bit := gates[i+1]
+
+		for {
+			a, b := rng.IntN(len(bit)), rng.IntN(len(bit))
+
+			// Swapping the two inputs of the OR gate would change nothing.
+			if a == b || a+b == 4 && a%2 == 1 {
+				continue
+			}
+
+			bit[a].out, bit[b].out = bit[b].out, bit[a].out
+			if acyclic(gates) {
+				break
+			}
+			bit[a].out, bit[b].out = bit[b].out, bit[a].out
+		}
+	}
+
+	var out strings.Builder
+	for i := range bits {
+		fmt.Fprintf(&out, "%s: %d\n", x(i), rng.IntN(2))
+	}
+	for i := range bits {
+		fmt.Fprintf(&out, "%s: %d\n", y(i), rng.IntN(2))
+	}
+	out.WriteByte('\n')
+
+	lines := make([]string, 0, 5*bits)
+	for _, bit := range gates {
+		for _, g := range bit {
+			left, right := g.left, g.right
+			if rng.IntN(2) == 0 {
+				left, right = right, left
+			}
+			lines = append(lines, fmt.Sprintf("%s %s %s -> %s\n", left, g.op, right, g.out))
+		}
+	}
+	rng.Shuffle(len(lines), func(i, j int) { lines[i], lines[j] = lines[j], lines[i] })
+	out.WriteString(strings.Join(lines, ""))
+
+	return []byte(out.String())
+}
+
+// acyclic reports whether every wire in the circuit can be resolved.
+func acyclic(gates [][]*adderGate) bool {
+	setters := make(map[string]*adderGate)
+	for _, bit := range gates {
+		for _, g := range bit {
+			setters[g.out] = g
+		}
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+
+	var visit func(wire string) bool
+	visit = func(wire string) bool {
+		g, ok := setters[wire]
+		if !ok || state[wire] == done {
+			return true
+		}
+		if state[wire] == visiting {
+			return false
+		}
+
+		state[wire] = visiting
+		if !visit(g.left) || !visit(g.right) {
+			return false
+		}
+		state[wire] = done
+		return true
+	}
+
+	for wire := range setters {
+		if !visit(wire) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,47 @@
+package generate
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// Day25 generates lock and key schematics, five pins wide and seven rows
+// tall, about half of each.
+func Day25(rng *rand.Rand, schematics int) []byte {
+	const (
+		cylinders = 5
+		height    = 7
+	)
+
+	blobs := make([]string, 0, schematics)
+
+	for range schematics {
+		lock := rng.IntN(2) == 0
+		heights := make([]int, cylinders)
+		for i := range heights {
+			heights[i] = rng.IntN(height - 1)
+		}
+
+		var blob strings.Builder
+		for row := range height {
+			for _, h := range heights {
+				// Locks fill down from the top row, keys up from the bottom.
+				filled := row <= h
+				if !lock {
+					filled = height-1-row <= h
+				}
+
+				if filled {
+					blob.WriteByte('#')
+				} else {
+					blob.WriteByte('.')
+				}
+			}
+			blob.WriteByte('\n')
+		}
+
+		blobs = append(blobs, blob.String())
+	}
+
+	return []byte(strings.Join(blobs, "\n"))
+}
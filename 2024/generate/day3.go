@@ -0,0 +1,49 @@
+package generate
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+var day3Noise = []string{
+	"mul(4*", "mul(6,9!", "?(12,34)", "mul ( 2 , 4 )", "mul(1234,5)", "mul[3,7]",
+	"from()", "select()", "who()", "why()", "when()", "where()", "how()",
+	"don't", "do(", "%&", "#", "'", "[", "]", "<", ">", "{", "}", "+", "-", " ",
+}
+
+// Day3 generates lines of corrupted memory containing the given number of
+// valid instructions: mostly mul(X,Y) with one to three digit operands, the
+// rest do() and don't(), buried in noise that looks almost like them.
+func Day3(rng *rand.Rand, instructions int) []byte {
+	var out strings.Builder
+	const lineLength = 3000
+	column := 0
+
+	for range instructions {
+		for range rng.IntN(6) {
+			out.WriteString(day3Noise[rng.IntN(len(day3Noise))])
+		}
+
+		var instruction string
+		switch rng.IntN(12) {
+		case 0:
+			instruction = "do()"
+		case 1:
+			instruction = "don't()"
+		default:
+			instruction = fmt.Sprintf("mul(%d,%d)", between(rng, 1, 999), between(rng, 1, 999))
+		}
+
+		out.WriteString(instruction)
+		column += len(instruction)
+
+		if column > lineLength {
+			out.WriteByte('\n')
+			column = 0
+		}
+	}
+	out.WriteByte('\n')
+
+	return []byte(out.String())
+}
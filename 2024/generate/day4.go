@@ -0,0 +1,34 @@
+package generate
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// Day4 generates a square word search made of the letters of XMAS, with a
+// few extra words written over the noise in every direction.
+func Day4(rng *rand.Rand, size int) []byte {
+	const letters = "XMAS"
+	g := newGrid(size, size, '.')
+
+	for y := range g {
+		for x := range g[y] {
+			g[y][x] = letters[rng.IntN(len(letters))]
+		}
+	}
+
+	for range size * size / 20 {
+		start := image.Point{X: rng.IntN(size), Y: rng.IntN(size)}
+		step := image.Point{X: between(rng, -1, 1), Y: between(rng, -1, 1)}
+
+		if step == (image.Point{}) || !g.in(start.Add(step.Mul(len(letters)-1))) {
+			continue
+		}
+
+		for i := range len(letters) {
+			g.set(start.Add(step.Mul(i)), letters[i])
+		}
+	}
+
+	return g.bytes()
+}
@@ -0,0 +1,50 @@
+package generate
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Day5 generates ordering rules for every pair of 49 two-digit pages, then
+// updates with an odd number of pages, roughly half of them already in order.
+func Day5(rng *rand.Rand, updates int) []byte {
+	pages := rng.Perm(90)[:49]
+	for i := range pages {
+		pages[i] += 10
+	}
+
+	var out strings.Builder
+
+	rules := make([]string, 0, len(pages)*len(pages)/2)
+	for i := range pages {
+		for j := i + 1; j < len(pages); j++ {
+			rules = append(rules, fmt.Sprintf("%d|%d\n", pages[i], pages[j]))
+		}
+	}
+	rng.Shuffle(len(rules), func(i, j int) { rules[i], rules[j] = rules[j], rules[i] })
+
+	for _, rule := range rules {
+		out.WriteString(rule)
+	}
+	out.WriteByte('\n')
+
+	for range updates {
+		order := rng.Perm(len(pages))[:between(rng, 2, 11)*2+1]
+
+		if rng.IntN(2) == 0 {
+			slices.Sort(order)
+		}
+
+		for i, index := range order {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			fmt.Fprint(&out, pages[index])
+		}
+		out.WriteByte('\n')
+	}
+
+	return []byte(out.String())
+}
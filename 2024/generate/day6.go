@@ -0,0 +1,58 @@
+package generate
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// Day6 generates a square lab with scattered obstructions and a guard facing
+// up, re-rolling the layout until the guard's patrol leaves the map. The
+// solver keeps the map in a fixed array, so sizes are limited to 130 like
+// the real inputs.
+func Day6(rng *rand.Rand, size int) []byte {
+	size = min(size, 130)
+
+	for {
+		g := newGrid(size, size, '.')
+
+		for range size * size / 20 {
+			g.set(image.Point{X: rng.IntN(size), Y: rng.IntN(size)}, '#')
+		}
+
+		guard := image.Point{X: rng.IntN(size), Y: rng.IntN(size)}
+		g.set(guard, '^')
+
+		if escapes(g, guard) {
+			return g.bytes()
+		}
+	}
+}
+
+// escapes walks the guard's patrol, turning right at each obstruction.
+func escapes(g grid, guard image.Point) bool {
+	type state struct {
+		position  image.Point
+		direction int
+	}
+
+	seen := make(map[state]struct{})
+	current := state{position: guard}
+
+	for {
+		if _, ok := seen[current]; ok {
+			return false
+		}
+		seen[current] = struct{}{}
+
+		next := current.position.Add(directions[current.direction])
+		if !g.in(next) {
+			return true
+		}
+
+		if g.at(next) == '#' {
+			current.direction = (current.direction + 1) % len(directions)
+		} else {
+			current.position = next
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package generate
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Day7 generates calibration equations with three to twelve operands. Most
+// targets are built by applying random +, * and || operators left to right;
+// the rest are nudged so they are probably unreachable. Operands are kept to
+// fifteen digits in total so that no combination of operators overflows.
+func Day7(rng *rand.Rand, lines int) []byte {
+	var out strings.Builder
+
+	for range lines {
+		count := between(rng, 3, 12)
+		operands := make([]int, 0, count)
+		digits := 0
+
+		for len(operands) < count {
+			operand := between(rng, 1, 999)
+			length := len(strconv.Itoa(operand))
+			if digits+length > 15 {
+				break
+			}
+			digits += length
+			operands = append(operands, operand)
+		}
+
+		target := operands[0]
+		for _, operand := range operands[1:] {
+			switch rng.IntN(3) {
+			case 0:
+				target += operand
+			case 1:
+				target *= operand
+			default:
+				target, _ = strconv.Atoi(strconv.Itoa(target) + strconv.Itoa(operand))
+			}
+		}
+
+		if rng.IntN(3) == 0 {
+			target += between(rng, 1, 9)
+		}
+
+		fmt.Fprintf(&out, "%d:", target)
+		for _, operand := range operands {
+			fmt.Fprintf(&out, " %d", operand)
+		}
+		out.WriteByte('\n')
+	}
+
+	return []byte(out.String())
+}
@@ -0,0 +1,27 @@
+package generate
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// Day8 generates a square map of antennas, with three or four antennas on
+// each frequency and about one frequency per ten rows. Sizes below 3 are
+// raised to 3, to leave room for the antennas.
+func Day8(rng *rand.Rand, size int) []byte {
+	const frequencies = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	size = max(size, 3)
+	g := newGrid(size, size, '.')
+
+	for _, f := range rng.Perm(len(frequencies))[:min(len(frequencies), max(1, size*size/500))] {
+		for placed := between(rng, 3, 4); placed > 0; {
+			p := image.Point{X: rng.IntN(size), Y: rng.IntN(size)}
+			if g.at(p) == '.' {
+				g.set(p, frequencies[f])
+				placed--
+			}
+		}
+	}
+
+	return g.bytes()
+}
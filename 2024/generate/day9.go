@@ -0,0 +1,21 @@
+package generate
+
+import (
+	"math/rand/v2"
+)
+
+// Day9 generates a disk map for the given number of files. Files are one to
+// nine blocks long, with zero to nine blocks of free space after each one
+// except the last.
+func Day9(rng *rand.Rand, files int) []byte {
+	out := make([]byte, 0, files*2)
+
+	for i := range files {
+		if i > 0 {
+			out = append(out, byte('0'+rng.IntN(10)))
+		}
+		out = append(out, byte('0'+between(rng, 1, 9)))
+	}
+
+	return append(out, '\n')
+}
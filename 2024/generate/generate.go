@@ -0,0 +1,198 @@
+// Package generate produces random, structurally valid puzzle inputs for
+// each day, for stress testing the solvers at scales the real inputs never
+// reach.
+//
+// Every generator is deterministic for a given seed. Scale means something
+// different for each day (usually the size of a grid, or a number of lines);
+// DefaultScale matches the real inputs, which is what the solvers with sizes
+// baked into them expect.
+package generate
+
+import (
+	"image"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+type Generator struct {
+	Description  string
+	DefaultScale int
+	Generate     func(rng *rand.Rand, scale int, options Options) []byte
+}
+
+// Options tune the generators beyond their scale. Most days have none.
+type Options struct {
+	// Swaps is how many pairs of gate outputs day 24 swaps.
+	Swaps int
+}
+
+// DefaultSwaps is the number of swapped pairs in the real day 24 inputs.
+const DefaultSwaps = 4
+
+// plain adapts a generator which takes no options.
+func plain(generate func(rng *rand.Rand, scale int) []byte) func(*rand.Rand, int, Options) []byte {
+	return func(rng *rand.Rand, scale int, _ Options) []byte {
+		return generate(rng, scale)
+	}
+}
+
+var Generators = map[int]Generator{
+	1:  {"pairs of location IDs (lines)", 1000, plain(Day1)},
+	2:  {"reactor reports (lines)", 1000, plain(Day2)},
+	3:  {"corrupted memory (instructions)", 700, plain(Day3)},
+	4:  {"word search (size)", 140, plain(Day4)},
+	5:  {"page ordering rules and updates (updates)", 200, plain(Day5)},
+	6:  {"guard's lab map, guard escapes (size, at most 130)", 130, plain(Day6)},
+	7:  {"calibration equations (lines)", 850, plain(Day7)},
+	8:  {"antenna map (size)", 50, plain(Day8)},
+	9:  {"disk map (files)", 10000, plain(Day9)},
+	10: {"topographic map (size)", 50, plain(Day10)},
+	11: {"stones (stones)", 8, plain(Day11)},
+	12: {"garden plots (size)", 140, plain(Day12)},
+	13: {"claw machines (machines)", 320, plain(Day13)},
+	14: {"robot swarm with one unstacked second (robots)", 500, plain(Day14)},
+	15: {"warehouse and moves (size)", 50, plain(Day15)},
+	16: {"reindeer maze with a solution (size)", 141, plain(Day16)},
+	17: {"3-bit computer program (octal digits of A)", 10, plain(Day17)},
+	18: {"falling bytes with an initial solution (size)", 71, plain(Day18)},
+	19: {"towels and designs (designs)", 400, plain(Day19)},
+	20: {"single-track racetrack (size)", 141, plain(Day20)},
+	22: {"buyer secrets (buyers)", 2000, plain(Day22)},
+	23: {"LAN party network (computers)", 520, plain(Day23)},
+	24: {"ripple-carry adder with swapped pairs of gates (bits)", 45, Day24},
+	25: {"locks and keys (schematics)", 500, plain(Day25)},
+}
+
+// NewRand is the random source generators are expected to be given.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, 0xadc0de))
+}
+
+// between returns a random integer in [low, high].
+func between(rng *rand.Rand, low, high int) int {
+	return low + rng.IntN(high-low+1)
+}
+
+type grid [][]byte
+
+func newGrid(width, height int, fill byte) grid {
+	g := make(grid, height)
+	for y := range g {
+		g[y] = []byte(strings.Repeat(string(fill), width))
+	}
+	return g
+}
+
+func (g grid) in(p image.Point) bool {
+	return p.Y >= 0 && p.Y < len(g) && p.X >= 0 && p.X < len(g[p.Y])
+}
+
+func (g grid) at(p image.Point) byte {
+	return g[p.Y][p.X]
+}
+
+func (g grid) set(p image.Point, c byte) {
+	g[p.Y][p.X] = c
+}
+
+func (g grid) bytes() []byte {
+	var out strings.Builder
+	for _, row := range g {
+		out.Write(row)
+		out.WriteByte('\n')
+	}
+	return []byte(out.String())
+}
+
+var directions = [4]image.Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// perfectMaze carves a maze with exactly one route between any two open
+// cells. Open cells are at odd coordinates, so the size should be odd.
+func perfectMaze(rng *rand.Rand, width, height int) grid {
+	maze := newGrid(width, height, '#')
+	start := image.Point{X: 1, Y: 1}
+	maze.set(start, '.')
+	stack := []image.Point{start}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		options := make([]image.Point, 0, 4)
+
+		for _, d := range directions {
+			next := current.Add(d.Mul(2))
+			if next.X > 0 && next.Y > 0 && next.X < width-1 && next.Y < height-1 && maze.at(next) == '#' {
+				options = append(options, d)
+			}
+		}
+
+		if len(options) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		d := options[rng.IntN(len(options))]
+		maze.set(current.Add(d), '.')
+		maze.set(current.Add(d.Mul(2)), '.')
+		stack = append(stack, current.Add(d.Mul(2)))
+	}
+
+	return maze
+}
+
+// route finds the shortest route between two open cells of a grid, or nil
+// if there is none.
+func route(g grid, from, to image.Point, open func(byte) bool) []image.Point {
+	previous := map[image.Point]image.Point{from: from}
+	queue := []image.Point{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == to {
+			path := []image.Point{to}
+			for current != from {
+				current = previous[current]
+				path = append(path, current)
+			}
+			slices.Reverse(path)
+			return path
+		}
+
+		for _, d := range directions {
+			next := current.Add(d)
+			if _, seen := previous[next]; seen || !g.in(next) || !open(g.at(next)) {
+				continue
+			}
+			previous[next] = current
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+// names returns count distinct random lowercase names of the given length,
+// none of which start with one of the reserved letters.
+func names(rng *rand.Rand, count, length int, reserved string) []string {
+	seen := make(map[string]struct{}, count)
+	result := make([]string, 0, count)
+
+	for len(result) < count {
+		name := make([]byte, length)
+		for i := range name {
+			name[i] = byte('a' + rng.IntN(26))
+		}
+		if strings.IndexByte(reserved, name[0]) >= 0 {
+			continue
+		}
+		if _, ok := seen[string(name)]; ok {
+			continue
+		}
+		seen[string(name)] = struct{}{}
+		result = append(result, string(name))
+	}
+
+	return result
+}
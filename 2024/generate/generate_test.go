@@ -0,0 +1,100 @@
+package generate_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"tea-cats.co.uk/aoc/2024/day14"
+	"tea-cats.co.uk/aoc/2024/day16"
+	"tea-cats.co.uk/aoc/2024/day17"
+	"tea-cats.co.uk/aoc/2024/day23"
+	"tea-cats.co.uk/aoc/2024/day24"
+	"tea-cats.co.uk/aoc/2024/day6"
+	"tea-cats.co.uk/aoc/2024/day7"
+	"tea-cats.co.uk/aoc/2024/day9"
+	"tea-cats.co.uk/aoc/2024/generate"
+	"testing"
+	"time"
+)
+
+// parsers are the loaders of the days which take the raw input, to check
+// that what is generated can be read. The other days read their input file
+// directly.
+var parsers = map[int]func([]byte) error{
+	6:  func(raw []byte) error { _, err := day6.LoadData(raw); return err },
+	7:  func(raw []byte) error { _, err := day7.LoadData(raw); return err },
+	9:  func(raw []byte) error { _, err := day9.LoadData(raw); return err },
+	14: func(raw []byte) error { _, err := day14.LoadData(raw); return err },
+	16: func(raw []byte) error { _, err := day16.LoadData(raw); return err },
+	17: func(raw []byte) error { _, err := day17.LoadData(raw); return err },
+	23: func(raw []byte) error { _, err := day23.LoadData(raw); return err },
+	24: func(raw []byte) error { _, err := day24.LoadData(raw); return err },
+}
+
+// TestGenerators runs every generator at a few small scales and the default
+// one, checking each finishes, is the same for the same seed, and parses.
+func TestGenerators(t *testing.T) {
+	options := generate.Options{Swaps: generate.DefaultSwaps}
+
+	for day, g := range generate.Generators {
+		for _, scale := range []int{1, 2, 10, g.DefaultScale} {
+			t.Run(fmt.Sprintf("day%d/%d", day, scale), func(t *testing.T) {
+				t.Parallel()
+
+				done := make(chan []byte, 1)
+				go func() { done <- g.Generate(generate.NewRand(uint64(scale)), scale, options) }()
+
+				var input []byte
+				select {
+				case input = <-done:
+				case <-time.After(30 * time.Second):
+					t.Fatal("did not finish")
+				}
+
+				if len(input) == 0 || input[len(input)-1] != '\n' {
+					t.Errorf("got %q, want lines ending in a newline", input)
+				}
+				if again := g.Generate(generate.NewRand(uint64(scale)), scale, options); !bytes.Equal(input, again) {
+					t.Error("the same seed gave a different input")
+				}
+
+				if parse, ok := parsers[day]; ok {
+					if err := parse(input); err != nil {
+						t.Error(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestDay6Limit checks that day 6's maps are kept small enough to load.
+func TestDay6Limit(t *testing.T) {
+	if _, err := day6.LoadData(generate.Day6(generate.NewRand(1), 200)); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestOptions checks that day 24's generator swaps as many pairs as it is
+// asked to, rather than the default.
+func TestOptions(t *testing.T) {
+	for _, swaps := range []int{0, 1, generate.DefaultSwaps} {
+		got := generate.Generators[24].Generate(generate.NewRand(1), 45, generate.Options{Swaps: swaps})
+		if want := generate.Adder(generate.NewRand(1), 45, swaps); !bytes.Equal(got, want) {
+			t.Errorf("%d swaps: got a different adder from Adder's", swaps)
+		}
+	}
+}
+
+// TestDay17Quine checks that every generated program has a part 2 answer.
+func TestDay17Quine(t *testing.T) {
+	for seed := uint64(1); seed <= 100; seed++ {
+		input, err := day17.LoadData(generate.Day17(generate.NewRand(seed), 10))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := day17.Part2(context.Background(), input); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+		}
+	}
+}
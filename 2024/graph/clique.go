@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"context"
+	"slices"
+)
+
+// Union is the nodes in either set.
+func (s Set) Union(other Set) Set {
+	if len(s) < len(other) {
+		s, other = other, s
+	}
+	result := s.Clone()
+	for i, word := range other {
+		result[i] |= word
+	}
+	return result
+}
+
+// above is the nodes in s numbered higher than node.
+func (s Set) above(node int) Set {
+	result := s.Clone()
+	for i := range result {
+		switch {
+		case i < node/64:
+			result[i] = 0
+		case i == node/64:
+			result[i] &^= (2 << (node % 64)) - 1
+		}
+	}
+	return result
+}
+
+// bronKerbosch finds the maximal cliques which contain all of clique, some of
+// candidates, and none of excluded, calling visit with each. Each step picks
+// a pivot with as many candidates as possible among its neighbours: every
+// maximal clique contains either the pivot or something not linked to it, so
+// only those need branching on. prune is asked before each branch whether it
+// is worth going on, given the clique so far and its candidates.
+func (g *Graph) bronKerbosch(ctx context.Context, clique []int, candidates, excluded Set, calls *int, prune func([]int, Set) bool, visit func([]int) error) error {
+	*calls++
+	if *calls%1024 == 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	if candidates.Empty() {
+		if excluded.Empty() && len(clique) > 0 {
+			return visit(clique)
+		}
+		return nil
+	}
+	if prune != nil && prune(clique, candidates) {
+		return nil
+	}
+
+	pivot, most := -1, -1
+	for node := range candidates.Union(excluded).All {
+		if count := candidates.IntersectLen(g.neighbours[node]); count > most {
+			pivot, most = node, count
+		}
+	}
+
+	for node := range candidates.Without(g.neighbours[pivot]).All {
+		neighbours := g.neighbours[node]
+		err := g.bronKerbosch(ctx, append(clique, node), candidates.Intersect(neighbours), excluded.Intersect(neighbours), calls, prune, visit)
+		if err != nil {
+			return err
+		}
+
+		candidates.Remove(node)
+		excluded.Add(node)
+	}
+
+	return nil
+}
+
+// MaximalCliques calls visit with every clique which cannot be made any
+// bigger, in no particular order. The slice is reused between calls, so
+// must be copied to be kept. An error from visit stops the search and is
+// returned.
+func (g *Graph) MaximalCliques(ctx context.Context, visit func(clique []int) error) error {
+	calls := 0
+	return g.bronKerbosch(ctx, make([]int, 0), g.Nodes(), make(Set, 0), &calls, nil, visit)
+}
+
+// MaximumClique finds a largest clique, with its nodes in order. Branches
+// which could not beat the best found so far are cut off, so this is much
+// quicker than looking at every maximal clique.
+func (g *Graph) MaximumClique(ctx context.Context) ([]int, error) {
+	best := make([]int, 0)
+	calls := 0
+
+	prune := func(clique []int, candidates Set) bool {
+		return len(clique)+candidates.Len() <= len(best)
+	}
+	err := g.bronKerbosch(ctx, make([]int, 0), g.Nodes(), make(Set, 0), &calls, prune, func(clique []int) error {
+		if len(clique) > len(best) {
+			best = slices.Clone(clique)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(best)
+	return best, nil
+}
+
+// CountCliques counts the cliques of exactly k nodes for which keep returns
+// true, or all of them if keep is nil. The nodes are given to keep in order,
+// and the slice is reused between calls.
+func (g *Graph) CountCliques(k int, keep func(clique []int) bool) int {
+	if k < 1 {
+		return 0
+	}
+
+	count := 0
+	clique := make([]int, 0, k)
+
+	// Each clique is only found once, by adding its nodes lowest first.
+	var extend func(candidates Set)
+	extend = func(candidates Set) {
+		if len(clique) == k {
+			if keep == nil || keep(clique) {
+				count++
+			}
+			return
+		}
+
+		for node := range candidates.All {
+			clique = append(clique, node)
+			extend(candidates.Intersect(g.neighbours[node]).above(node))
+			clique = clique[:len(clique)-1]
+		}
+	}
+	extend(g.Nodes())
+
+	return count
+}
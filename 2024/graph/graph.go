@@ -0,0 +1,169 @@
+// Package graph is an undirected graph of named nodes, with the adjacency of
+// each node kept as a bit set so that the clique searches can intersect
+// neighbourhoods a word at a time.
+package graph
+
+import (
+	"math/bits"
+)
+
+// Set is a set of nodes, as a bit per node.
+type Set []uint64
+
+func (s *Set) Add(node int) {
+	for len(*s) <= node/64 {
+		*s = append(*s, 0)
+	}
+	(*s)[node/64] |= 1 << (node % 64)
+}
+
+func (s *Set) Remove(node int) {
+	if node/64 < len(*s) {
+		(*s)[node/64] &^= 1 << (node % 64)
+	}
+}
+
+func (s Set) Has(node int) bool {
+	return node/64 < len(s) && s[node/64]&(1<<(node%64)) != 0
+}
+
+func (s Set) Len() int {
+	total := 0
+	for _, word := range s {
+		total += bits.OnesCount64(word)
+	}
+	return total
+}
+
+func (s Set) Empty() bool {
+	for _, word := range s {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Set) Clone() Set {
+	return append(Set(nil), s...)
+}
+
+// Intersect is the nodes in both sets.
+func (s Set) Intersect(other Set) Set {
+	result := make(Set, min(len(s), len(other)))
+	for i := range result {
+		result[i] = s[i] & other[i]
+	}
+	return result
+}
+
+// IntersectLen is len(s.Intersect(other)), without making the set.
+func (s Set) IntersectLen(other Set) int {
+	total := 0
+	for i := range min(len(s), len(other)) {
+		total += bits.OnesCount64(s[i] & other[i])
+	}
+	return total
+}
+
+// Without is the nodes in s but not in other.
+func (s Set) Without(other Set) Set {
+	result := s.Clone()
+	for i := range min(len(s), len(other)) {
+		result[i] &^= other[i]
+	}
+	return result
+}
+
+// All iterates over the nodes in the set, lowest first.
+func (s Set) All(yield func(int) bool) {
+	for i, word := range s {
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			if !yield(i*64 + bit) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
+
+// Graph is an undirected graph without loops. Nodes are numbered in the
+// order they are first seen, and looked up by name.
+type Graph struct {
+	names      []string
+	index      map[string]int
+	neighbours []Set
+}
+
+func New() *Graph {
+	return &Graph{index: make(map[string]int)}
+}
+
+// Node returns the number of the named node, adding it if it is new.
+func (g *Graph) Node(name string) int {
+	if node, ok := g.index[name]; ok {
+		return node
+	}
+	g.index[name] = len(g.names)
+	g.names = append(g.names, name)
+	g.neighbours = append(g.neighbours, nil)
+	return len(g.names) - 1
+}
+
+// Lookup finds a node by name, without adding it.
+func (g *Graph) Lookup(name string) (int, bool) {
+	node, ok := g.index[name]
+	return node, ok
+}
+
+func (g *Graph) Name(node int) string {
+	return g.names[node]
+}
+
+// Names is the names of the nodes given.
+func (g *Graph) Names(nodes []int) []string {
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		names[i] = g.names[node]
+	}
+	return names
+}
+
+// Len is the number of nodes.
+func (g *Graph) Len() int {
+	return len(g.names)
+}
+
+// Link adds an edge between two nodes. Links from a node to itself are
+// ignored.
+func (g *Graph) Link(a, b int) {
+	if a == b {
+		return
+	}
+	g.neighbours[a].Add(b)
+	g.neighbours[b].Add(a)
+}
+
+// LinkNames is Link by name, adding the nodes if they are new.
+func (g *Graph) LinkNames(a, b string) {
+	g.Link(g.Node(a), g.Node(b))
+}
+
+func (g *Graph) Linked(a, b int) bool {
+	return g.neighbours[a].Has(b)
+}
+
+// Neighbours is the set of nodes linked to node. It must not be modified.
+func (g *Graph) Neighbours(node int) Set {
+	return g.neighbours[node]
+}
+
+// Nodes is the set of every node.
+func (g *Graph) Nodes() Set {
+	all := make(Set, 0, (len(g.names)+63)/64)
+	for node := range g.names {
+		all.Add(node)
+	}
+	return all
+}
@@ -0,0 +1,156 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// randomGraph links each pair of n nodes with the given probability. The
+// nodes are spread out so that the sets span several words.
+func randomGraph(rng *rand.Rand, n int, probability float64) *Graph {
+	g := New()
+	for i := range n {
+		g.Node(fmt.Sprintf("node-%d", i))
+	}
+	for a := range n {
+		for b := a + 1; b < n; b++ {
+			if rng.Float64() < probability {
+				g.Link(a, b)
+			}
+		}
+	}
+	return g
+}
+
+// bruteForce checks every subset of nodes, returning the maximal cliques as
+// bit masks, and the number of cliques of each size.
+func bruteForce(g *Graph) (maximal map[uint64]bool, counts []int) {
+	n := g.Len()
+	clique := func(mask uint64) bool {
+		for a := range n {
+			for b := a + 1; b < n; b++ {
+				if mask&(1<<a) != 0 && mask&(1<<b) != 0 && !g.Linked(a, b) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	maximal = make(map[uint64]bool)
+	counts = make([]int, n+1)
+	for mask := uint64(1); mask < 1<<n; mask++ {
+		if !clique(mask) {
+			continue
+		}
+		counts[bits.OnesCount64(mask)]++
+
+		extendable := false
+		for node := range n {
+			if mask&(1<<node) == 0 && clique(mask|1<<node) {
+				extendable = true
+				break
+			}
+		}
+		if !extendable {
+			maximal[mask] = true
+		}
+	}
+
+	return maximal, counts
+}
+
+func TestCliques(t *testing.T) {
+	rng := rand.New(rand.NewPCG(50, 0))
+
+	for range 200 {
+		g := randomGraph(rng, 1+rng.IntN(12), rng.Float64())
+		wantMaximal, wantCounts := bruteForce(g)
+
+		gotMaximal := make(map[uint64]bool)
+		err := g.MaximalCliques(context.Background(), func(clique []int) error {
+			mask := uint64(0)
+			for _, node := range clique {
+				mask |= 1 << node
+			}
+			if gotMaximal[mask] {
+				t.Errorf("clique %v found twice", clique)
+			}
+			gotMaximal[mask] = true
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(gotMaximal) != len(wantMaximal) {
+			t.Fatalf("found %d maximal cliques, want %d", len(gotMaximal), len(wantMaximal))
+		}
+		for mask := range wantMaximal {
+			if !gotMaximal[mask] {
+				t.Fatalf("clique %b not found", mask)
+			}
+		}
+
+		largest := 0
+		for size, count := range wantCounts {
+			if count > 0 {
+				largest = size
+			}
+		}
+		best, err := g.MaximumClique(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(best) != largest || !slices.IsSorted(best) {
+			t.Errorf("maximum clique %v, want %d nodes", best, largest)
+		}
+
+		for k := 1; k < len(wantCounts); k++ {
+			if got := g.CountCliques(k, nil); got != wantCounts[k] {
+				t.Errorf("%d cliques of %d nodes, want %d", got, k, wantCounts[k])
+			}
+		}
+	}
+}
+
+func TestCountCliquesKeep(t *testing.T) {
+	g := New()
+	for _, edge := range [][2]string{{"ta", "b"}, {"b", "c"}, {"c", "ta"}, {"b", "d"}, {"c", "d"}} {
+		g.LinkNames(edge[0], edge[1])
+	}
+
+	withT := g.CountCliques(3, func(clique []int) bool {
+		return slices.ContainsFunc(g.Names(clique), func(name string) bool { return name[0] == 't' })
+	})
+	if withT != 1 {
+		t.Errorf("got %d triangles with t, want 1", withT)
+	}
+	if all := g.CountCliques(3, nil); all != 2 {
+		t.Errorf("got %d triangles, want 2", all)
+	}
+}
+
+func TestLargeSets(t *testing.T) {
+	rng := rand.New(rand.NewPCG(50, 1))
+	g := randomGraph(rng, 300, 0.05)
+
+	// A clique among nodes in different words of the sets.
+	party := []int{3, 70, 140, 199, 260, 299}
+	for i, a := range party {
+		for _, b := range party[i+1:] {
+			g.Link(a, b)
+		}
+	}
+
+	best, err := g.MaximumClique(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(best) < len(party) {
+		t.Errorf("maximum clique %v is smaller than %v", best, party)
+	}
+}
@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Interval is the half-open range of integers [Start, End).
+type Interval struct {
+	Start int
+	End   int
+}
+
+func (i Interval) Len() int {
+	return i.End - i.Start
+}
+
+func (i Interval) Contains(x int) bool {
+	return i.Start <= x && x < i.End
+}
+
+func (i Interval) Overlaps(other Interval) bool {
+	return i.Start < other.End && other.Start < i.End
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("[%d,%d)", i.Start, i.End)
+}
+
+// IntervalSet is a set of integers stored as sorted, disjoint intervals.
+// Touching intervals are always merged, so [1,3) and [3,5) are held as [1,5).
+type IntervalSet struct {
+	intervals []Interval
+}
+
+func NewIntervalSet(intervals ...Interval) IntervalSet {
+	set := IntervalSet{}
+	for _, i := range intervals {
+		set.Insert(i.Start, i.End)
+	}
+	return set
+}
+
+// search returns the index of the first interval with an end after x.
+func (s *IntervalSet) search(x int) int {
+	return sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].End > x })
+}
+
+// Insert adds [start, end) to the set, merging it with any intervals it
+// overlaps or touches.
+func (s *IntervalSet) Insert(start, end int) {
+	if start >= end {
+		return
+	}
+
+	// Intervals which end exactly at start are merged as well.
+	i := s.search(start - 1)
+	j := i
+	for ; j < len(s.intervals) && s.intervals[j].Start <= end; j++ {
+	}
+
+	merged := Interval{Start: start, End: end}
+	if i < j {
+		merged.Start = min(merged.Start, s.intervals[i].Start)
+		merged.End = max(merged.End, s.intervals[j-1].End)
+	}
+
+	s.replace(i, j, merged)
+}
+
+// Remove takes [start, end) out of the set, splitting intervals if needed.
+func (s *IntervalSet) Remove(start, end int) {
+	if start >= end {
+		return
+	}
+
+	i := s.search(start)
+	j := i
+	for ; j < len(s.intervals) && s.intervals[j].Start < end; j++ {
+	}
+
+	if i == j {
+		return
+	}
+
+	remaining := make([]Interval, 0, 2)
+	if s.intervals[i].Start < start {
+		remaining = append(remaining, Interval{Start: s.intervals[i].Start, End: start})
+	}
+	if s.intervals[j-1].End > end {
+		remaining = append(remaining, Interval{Start: end, End: s.intervals[j-1].End})
+	}
+
+	s.replace(i, j, remaining...)
+}
+
+// replace swaps the intervals in [i, j) for the given ones.
+func (s *IntervalSet) replace(i, j int, with ...Interval) {
+	tail := append(with, s.intervals[j:]...)
+	s.intervals = append(s.intervals[:i], tail...)
+}
+
+// Merge adds every interval from other into this set.
+func (s *IntervalSet) Merge(other IntervalSet) {
+	for _, i := range other.intervals {
+		s.Insert(i.Start, i.End)
+	}
+}
+
+func (s *IntervalSet) Contains(x int) bool {
+	i := s.search(x)
+	return i < len(s.intervals) && s.intervals[i].Contains(x)
+}
+
+// Overlaps reports whether any part of [start, end) is in the set.
+func (s *IntervalSet) Overlaps(start, end int) bool {
+	return len(s.Overlapping(start, end)) > 0
+}
+
+// Overlapping returns the intervals which share at least one value with
+// [start, end). The returned slice must not be modified.
+func (s *IntervalSet) Overlapping(start, end int) []Interval {
+	if start >= end {
+		return nil
+	}
+
+	i := s.search(start)
+	j := i
+	for ; j < len(s.intervals) && s.intervals[j].Start < end; j++ {
+	}
+
+	return s.intervals[i:j]
+}
+
+// FirstFit returns the lowest interval which is at least length long.
+func (s *IntervalSet) FirstFit(length int) (Interval, bool) {
+	for _, i := range s.intervals {
+		if i.Len() >= length {
+			return i, true
+		}
+	}
+	return Interval{}, false
+}
+
+// Complement returns every value in [start, end) that is not in this set.
+func (s *IntervalSet) Complement(start, end int) IntervalSet {
+	result := IntervalSet{}
+	position := start
+
+	for _, i := range s.Overlapping(start, end) {
+		if i.Start > position {
+			result.intervals = append(result.intervals, Interval{Start: position, End: i.Start})
+		}
+		position = i.End
+	}
+
+	if position < end {
+		result.intervals = append(result.intervals, Interval{Start: position, End: end})
+	}
+
+	return result
+}
+
+// Len is the total count of values covered by the set.
+func (s *IntervalSet) Len() int {
+	total := 0
+	for _, i := range s.intervals {
+		total += i.Len()
+	}
+	return total
+}
+
+// Intervals returns the sorted intervals in the set. The returned slice must
+// not be modified.
+func (s *IntervalSet) Intervals() []Interval {
+	return s.intervals
+}
+
+func (s *IntervalSet) String() string {
+	return fmt.Sprint(s.intervals)
+}
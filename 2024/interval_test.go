@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIntervalSetInsert(t *testing.T) {
+	tests := []struct {
+		name    string
+		inserts []Interval
+		want    []Interval
+	}{
+		{"disjoint", []Interval{{5, 7}, {1, 3}}, []Interval{{1, 3}, {5, 7}}},
+		{"touching after", []Interval{{1, 3}, {3, 5}}, []Interval{{1, 5}}},
+		{"touching before", []Interval{{3, 5}, {1, 3}}, []Interval{{1, 5}}},
+		{"overlapping", []Interval{{1, 4}, {3, 6}}, []Interval{{1, 6}}},
+		{"inside", []Interval{{1, 10}, {3, 6}}, []Interval{{1, 10}}},
+		{"covering several", []Interval{{1, 2}, {4, 5}, {7, 8}, {0, 9}}, []Interval{{0, 9}}},
+		{"bridging a gap", []Interval{{1, 3}, {5, 7}, {3, 5}}, []Interval{{1, 7}}},
+		{"empty", []Interval{{1, 3}, {4, 4}, {6, 5}}, []Interval{{1, 3}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := NewIntervalSet(test.inserts...)
+			if got := set.Intervals(); !slices.Equal(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIntervalSetRemove(t *testing.T) {
+	tests := []struct {
+		name          string
+		start, end    int
+		want          []Interval
+		wantContained []int
+	}{
+		{"splitting", 3, 5, []Interval{{0, 3}, {5, 10}, {20, 30}}, []int{2, 5}},
+		{"the start", 0, 2, []Interval{{2, 10}, {20, 30}}, []int{2}},
+		{"the end", 8, 10, []Interval{{0, 8}, {20, 30}}, []int{7}},
+		{"across a gap", 5, 25, []Interval{{0, 5}, {25, 30}}, []int{4, 25}},
+		{"everything", -5, 50, nil, nil},
+		{"nothing", 12, 18, []Interval{{0, 10}, {20, 30}}, []int{9, 20}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := NewIntervalSet(Interval{0, 10}, Interval{20, 30})
+			set.Remove(test.start, test.end)
+
+			if got := set.Intervals(); !slices.Equal(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+			for _, x := range test.wantContained {
+				if !set.Contains(x) {
+					t.Errorf("%d should still be in %v", x, set.Intervals())
+				}
+			}
+			if test.start < test.end && set.Contains(test.start) {
+				t.Errorf("%d should have been removed from %v", test.start, set.Intervals())
+			}
+		})
+	}
+}
+
+func TestIntervalSetFirstFit(t *testing.T) {
+	set := NewIntervalSet(Interval{0, 2}, Interval{5, 9}, Interval{12, 20})
+
+	tests := []struct {
+		length int
+		want   Interval
+		ok     bool
+	}{
+		{1, Interval{0, 2}, true},
+		{3, Interval{5, 9}, true},
+		{5, Interval{12, 20}, true},
+		{8, Interval{12, 20}, true},
+		{9, Interval{}, false},
+	}
+
+	for _, test := range tests {
+		got, ok := set.FirstFit(test.length)
+		if got != test.want || ok != test.ok {
+			t.Errorf("FirstFit(%d) = %v, %t, want %v, %t", test.length, got, ok, test.want, test.ok)
+		}
+	}
+
+	empty := IntervalSet{}
+	if _, ok := empty.FirstFit(1); ok {
+		t.Error("found a fit in an empty set")
+	}
+}
+
+func TestIntervalSetComplement(t *testing.T) {
+	set := NewIntervalSet(Interval{0, 3}, Interval{5, 8}, Interval{10, 12})
+
+	tests := []struct {
+		name       string
+		start, end int
+		want       []Interval
+	}{
+		{"whole range", 0, 12, []Interval{{3, 5}, {8, 10}}},
+		{"bounds inside intervals", 1, 11, []Interval{{3, 5}, {8, 10}}},
+		{"bounds in gaps", 4, 9, []Interval{{4, 5}, {8, 9}}},
+		{"beyond the set", -2, 15, []Interval{{-2, 0}, {3, 5}, {8, 10}, {12, 15}}},
+		{"all covered", 5, 8, nil},
+		{"all uncovered", 3, 5, []Interval{{3, 5}}},
+		{"empty range", 4, 4, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := set.Complement(test.start, test.end)
+			if !slices.Equal(got.Intervals(), test.want) {
+				t.Errorf("got %v, want %v", got.Intervals(), test.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,10 @@ package utils
 
 import (
 	"image"
+	"io"
 	"log"
-	"os"
 	"runtime"
+	"tea-cats.co.uk/aoc/inputs"
 	"time"
 )
 
@@ -50,7 +51,12 @@ func (m *Set[T]) AddAll(v []T) {
 	}
 }
 
-func CloseWithLog(file *os.File) {
+// OpenInput opens the input for a day of this year, decrypting it if needed.
+func OpenInput(day int) (io.ReadCloser, error) {
+	return inputs.Open(2024, day)
+}
+
+func CloseWithLog(file io.Closer) {
 	err := file.Close()
 	if err != nil {
 		log.Fatal(err)
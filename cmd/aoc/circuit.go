@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"tea-cats.co.uk/aoc/2024/day24"
+	"tea-cats.co.uk/aoc/2024/day24/circuit"
+	"tea-cats.co.uk/aoc/inputs"
+)
+
+const circuitUsage = "expected: circuit dot|verilog [flags] [file]"
+
+// runCircuit exports a 2024 day 24 circuit for Graphviz or Verilog tools.
+// Circuits are read from the file given, or the day's input by default.
+func runCircuit(args []string) error {
+	if len(args) == 0 {
+		return errors.New(circuitUsage)
+	}
+
+	flags := flag.NewFlagSet("circuit "+args[0], flag.ExitOnError)
+	repaired := flags.Bool("repaired", false, "export the circuit after swapping the wires part 2 finds")
+	highlight := flags.Bool("highlight", true, "with dot, highlight the gates part 2 swaps")
+	module := flags.String("module", "adder", "with verilog, the name of the module")
+	positional := parseFlags(flags, args[1:])
+
+	var raw []byte
+	var err error
+	switch len(positional) {
+	case 0:
+		raw, err = inputs.Read(2024, 24)
+	case 1:
+		raw, err = os.ReadFile(positional[0])
+	default:
+		return errors.New(circuitUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	c, err := circuit.Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	// The repair works on its own copy unless the repaired circuit is
+	// wanted, so that the highlights are on the circuit as given.
+	suspects := make([]int, 0)
+	if *repaired || (*highlight && args[0] == "dot") {
+		fixed := c
+		if !*repaired {
+			fixed, _ = circuit.Parse(raw)
+		}
+
+		swapped, err := day24.Repair(context.Background(), fixed)
+		if err != nil {
+			if *repaired {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "cannot highlight the swapped wires: %v\n", err)
+		}
+		for _, name := range swapped {
+			wire, _ := c.Wire(name)
+			suspects = append(suspects, wire)
+		}
+	}
+
+	switch args[0] {
+	case "dot":
+		if !*highlight {
+			suspects = nil
+		}
+		return c.WriteDOT(os.Stdout, suspects)
+	case "verilog":
+		return c.WriteVerilog(os.Stdout, *module)
+	}
+
+	return errors.New(circuitUsage)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"tea-cats.co.uk/aoc/examples"
+	"tea-cats.co.uk/aoc/inputs"
+)
+
+func runExamples(args []string) error {
+	flags := flag.NewFlagSet("examples", flag.ExitOnError)
+	pagePath := flags.String("page", "", "saved puzzle page (default from aoc fetch -puzzle)")
+	dir := flags.String("dir", "", "directory to write examples to (default the day's testdata)")
+	_ = flags.Parse(args)
+
+	values, err := parseInts(flags.Args(), "year", "day")
+	if err != nil {
+		return err
+	}
+	year, day := values[0], values[1]
+
+	if *pagePath == "" {
+		*pagePath = inputs.PuzzlePath(year, day)
+	}
+	if *dir == "" {
+		*dir = filepath.Join(fmt.Sprint(year), fmt.Sprintf("day%d", day), "testdata")
+	}
+
+	page, err := os.ReadFile(*pagePath)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := examples.Parse(page)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *pagePath, err)
+	}
+
+	if err := os.MkdirAll(*dir, 0755); err != nil {
+		return err
+	}
+
+	files := make([]string, len(parsed.Blocks))
+	for i, block := range parsed.Blocks {
+		files[i] = filepath.Join(*dir, fmt.Sprintf("example-%d.txt", i+1))
+
+		written, err := writeExample(files[i], []byte(block.Text))
+		if err != nil {
+			return err
+		}
+		if written {
+			fmt.Printf("Wrote %s (part %d, %d bytes)\n", files[i], block.Part, len(block.Text))
+		} else {
+			fmt.Printf("Skipped %s: already exists with different content\n", files[i])
+		}
+	}
+
+	if len(parsed.Proposals) == 0 {
+		fmt.Println("\nNo highlighted answers found")
+		return nil
+	}
+
+	fmt.Println("\nProposed test cases:")
+	for _, proposal := range parsed.Proposals {
+		fmt.Printf("\t\t{Name: \"part%d\", File: \"testdata/%s\", Solve: runner.Parsed(LoadData, Part%d), Want: %q},\n",
+			proposal.Part, filepath.Base(files[proposal.Block]), proposal.Part, proposal.Answer)
+	}
+
+	return nil
+}
+
+// writeExample writes an example file, unless there is already one there
+// with other content (which may well have been edited by hand). Empty files,
+// as created by aoc new, are replaced.
+func writeExample(path string, data []byte) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	if len(existing) > 0 && !bytes.Equal(existing, data) {
+		return false, nil
+	}
+
+	return true, os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"tea-cats.co.uk/aoc/inputs"
+	"tea-cats.co.uk/aoc/leaderboard"
+	"tea-cats.co.uk/aoc/site"
+	"time"
+)
+
+func runFetch(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	flags.StringVar(&inputs.Dir, "dir", inputs.Dir, "directory to cache inputs in (or set "+inputs.DirEnv+")")
+	baseURL := flags.String("url", site.DefaultBaseURL, "base URL of the site")
+	puzzle := flags.Bool("puzzle", false, "also save the puzzle page, for aoc examples")
+	_ = flags.Parse(args)
+
+	positional := flags.Args()
+	if len(positional) == 0 || len(positional) > 2 {
+		return errors.New("expected arguments: year [day]")
+	}
+
+	values, err := parseInts(positional, []string{"year", "day"}[:len(positional)]...)
+	if err != nil {
+		return err
+	}
+
+	// Without a day, fetch every day that is out so far.
+	year, days := values[0], values[1:]
+	allDays := len(days) == 0
+	if allDays {
+		for day := 1; day <= 25; day++ {
+			days = append(days, day)
+		}
+	}
+
+	// A missing session is only a problem if something is not cached yet.
+	session, err := site.LoadSession()
+	if err != nil && !errors.Is(err, site.ErrNoSession) {
+		return err
+	}
+
+	client := site.NewClient(session)
+	client.BaseURL = *baseURL
+
+	for _, day := range days {
+		if allDays && time.Now().Before(leaderboard.Unlock(year, day)) {
+			fmt.Printf("%d day %d is not out yet\n", year, day)
+			break
+		}
+
+		path := inputs.Path(year, day)
+
+		fetched, err := client.FetchInput(year, day, path)
+		if allDays && errors.Is(err, site.ErrNotReleased) {
+			fmt.Printf("%d day %d is not out yet\n", year, day)
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%d day %d: %w", year, day, err)
+		}
+
+		// The input may be cached in encrypted form instead.
+		if _, err := os.Stat(path); !fetched && err != nil {
+			path = inputs.EncryptedPath(year, day)
+		}
+		report(path, fetched)
+
+		if *puzzle {
+			path = inputs.PuzzlePath(year, day)
+
+			fetched, err = client.FetchPuzzle(year, day, path)
+			if err != nil {
+				return fmt.Errorf("%d day %d: %w", year, day, err)
+			}
+
+			report(path, fetched)
+		}
+	}
+
+	return nil
+}
+
+func report(path string, fetched bool) {
+	if fetched {
+		fmt.Printf("Fetched %s\n", path)
+	} else {
+		fmt.Printf("Already cached %s\n", path)
+	}
+}
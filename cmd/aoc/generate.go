@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"tea-cats.co.uk/aoc/2024/generate"
+)
+
+func runGenerate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	seed := flags.Uint64("seed", 1, "random seed; the same seed always gives the same input")
+	scale := flags.Int("scale", 0, "size of the input, meaning depends on the day (default the size of the real inputs)")
+	swaps := flags.Int("swaps", generate.DefaultSwaps, "pairs of gates to swap in day 24's adder")
+	output := flags.String("o", "", "file to write the input to (default stdout)")
+	list := flags.Bool("list", false, "list the days which have generators")
+	_ = flags.Parse(args)
+
+	if *list {
+		for _, day := range slices.Sorted(maps.Keys(generate.Generators)) {
+			g := generate.Generators[day]
+			fmt.Printf("day %2d  %-6d %s\n", day, g.DefaultScale, g.Description)
+		}
+		return nil
+	}
+
+	values, err := parseInts(flags.Args(), "year", "day")
+	if err != nil {
+		return err
+	}
+	year, day := values[0], values[1]
+
+	if year != 2024 {
+		return errors.New("generators only exist for 2024")
+	}
+
+	g, ok := generate.Generators[day]
+	if !ok {
+		return fmt.Errorf("no generator for day %d", day)
+	}
+
+	if *scale <= 0 {
+		*scale = g.DefaultScale
+	}
+
+	input := g.Generate(generate.NewRand(*seed), *scale, generate.Options{Swaps: *swaps})
+
+	if *output == "" {
+		_, err = os.Stdout.Write(input)
+		return err
+	}
+
+	return os.WriteFile(*output, input, 0644)
+}
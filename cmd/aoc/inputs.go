@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"tea-cats.co.uk/aoc/inputs"
+)
+
+func runInputs(args []string) error {
+	if len(args) == 0 || (args[0] != "lock" && args[0] != "unlock") {
+		return errors.New("expected: inputs lock|unlock [flags] [year]")
+	}
+	lock := args[0] == "lock"
+
+	flags := flag.NewFlagSet("inputs "+args[0], flag.ExitOnError)
+	flags.StringVar(&inputs.Dir, "dir", inputs.Dir, "directory the inputs are kept in (or set "+inputs.DirEnv+")")
+	keep := flags.Bool("keep", false, "keep the plain text files after locking")
+	_ = flags.Parse(args[1:])
+
+	year := "[0-9][0-9][0-9][0-9]"
+	if flags.NArg() > 1 {
+		return errors.New("expected: inputs lock|unlock [flags] [year]")
+	}
+	if flags.NArg() == 1 {
+		values, err := parseInts(flags.Args(), "year")
+		if err != nil {
+			return err
+		}
+		year = fmt.Sprint(values[0])
+	}
+
+	pattern := "input-*.txt"
+	if !lock {
+		pattern += inputs.EncryptedSuffix
+	}
+
+	files, err := filepath.Glob(filepath.Join(inputs.Dir, year, pattern))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no files matching %s", filepath.Join(inputs.Dir, year, pattern))
+	}
+
+	secret, err := inputs.LoadSecret()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if lock {
+			err = inputs.LockFile(secret, file, *keep)
+		} else {
+			err = inputs.UnlockFile(secret, file)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%sed %s\n", args[0], file)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"tea-cats.co.uk/aoc/leaderboard"
+	"tea-cats.co.uk/aoc/ledger"
+)
+
+func runLeaderboard(args []string) error {
+	if len(args) == 0 || args[0] != "stats" {
+		return errors.New("expected: leaderboard stats [flags] <file.json>")
+	}
+
+	flags := flag.NewFlagSet("leaderboard stats", flag.ExitOnError)
+	chart := flags.Bool("chart", false, "draw ranks over time as a chart instead of tables")
+	ledgerPath := flags.String("ledger", ledger.Path(), "answer history to compare against (or set "+ledger.PathEnv+")")
+	member := flags.String("member", "", "our name on the board, to compare with the ledger")
+	_ = flags.Parse(args[1:])
+
+	if flags.NArg() != 1 {
+		return errors.New("expected: leaderboard stats [flags] <file.json>")
+	}
+
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	board, err := leaderboard.Parse(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", flags.Arg(0), err)
+	}
+
+	if *chart {
+		return leaderboard.WriteChart(os.Stdout, board)
+	}
+
+	fmt.Printf("=== Star times (%d) ===\n\n", board.Event)
+	if err := leaderboard.WriteStarTimes(os.Stdout, board); err != nil {
+		return err
+	}
+
+	fmt.Printf("=== Rank after each day ===\n\n")
+	if err := leaderboard.WriteRanks(os.Stdout, board); err != nil {
+		return err
+	}
+
+	history, err := ledger.Open(*ledgerPath)
+	if err != nil {
+		return err
+	}
+	if len(history.Entries) == 0 {
+		return nil
+	}
+
+	fmt.Printf("\n=== Our solves from %s ===\n\n", *ledgerPath)
+	return leaderboard.WriteComparison(os.Stdout, board, history, *member)
+}
@@ -0,0 +1,98 @@
+// Command aoc is the tooling for working on puzzles: creating new days,
+// fetching inputs, running the registered solvers and submitting answers.
+//
+// It is expected to be run from the root of the repository, in the same way
+// as `go run ./2024/dayN/partM`.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"new", "<year> <day>", "create the package for a new day", runNew},
+	{"run", "[-variants] [-timeout d] <year> <day> [part] | <year> -all", "run the solvers for a day or a whole year", runRun},
+	{"watch", "[-interval d] [-debounce d] <year> <day>", "re-run a day's solvers and examples whenever it changes", runWatch},
+	{"fetch", "[-dir path] [-puzzle] <year> [day]", "download and cache puzzle inputs", runFetch},
+	{"inputs", "lock|unlock [-keep] [year]", "encrypt or decrypt the stored puzzle inputs", runInputs},
+	{"examples", "<year> <day>", "extract examples from a saved puzzle page", runExamples},
+	{"generate", "[-seed n] [-scale n] <year> <day>", "generate a random input for stress testing", runGenerate},
+	{"vm", "trace|debug|disasm|compile|asm [-a n] [file]", "work with 2024 day 17 programs", runVM},
+	{"circuit", "dot|verilog [-repaired] [file]", "export a 2024 day 24 circuit for Graphviz or Verilog", runCircuit},
+	{"leaderboard", "stats [-chart] <file.json>", "statistics for an exported private leaderboard", runLeaderboard},
+	{"submit", "[-wait] <year> <day> <part> [answer]", "submit an answer, computing it if not given", runSubmit},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	index := slices.IndexFunc(commands, func(c command) bool { return c.name == os.Args[1] })
+	if index < 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	if err := commands[index].run(os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: aoc <command> [arguments]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-40s %s\n", c.name+" "+c.usage, c.summary)
+	}
+}
+
+// parseFlags parses flags wherever they are among the positional arguments,
+// so that `aoc run 2024 -all` works as well as `aoc run -all 2024`. It
+// returns the positional arguments.
+func parseFlags(flags *flag.FlagSet, args []string) []string {
+	positional := make([]string, 0, len(args))
+
+	for {
+		_ = flags.Parse(args)
+		args = flags.Args()
+
+		if len(args) == 0 {
+			return positional
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseInts converts positional arguments into numbers, naming them in errors.
+func parseInts(args []string, names ...string) ([]int, error) {
+	if len(args) != len(names) {
+		return nil, fmt.Errorf("expected arguments: %s", strings.Join(names, " "))
+	}
+
+	values := make([]int, len(args))
+	for i, arg := range args {
+		value, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", names[i], arg)
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
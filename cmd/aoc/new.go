@@ -0,0 +1,29 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"tea-cats.co.uk/aoc/scaffold"
+)
+
+func runNew(args []string) error {
+	flags := flag.NewFlagSet("new", flag.ExitOnError)
+	sync := flags.Bool("sync", false, "only regenerate the list of registered days")
+	_ = flags.Parse(args)
+
+	if *sync {
+		return scaffold.WriteRegistry(".")
+	}
+
+	values, err := parseInts(flags.Args(), "year", "day")
+	if err != nil {
+		return err
+	}
+
+	if err := scaffold.New(".", values[0], values[1]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %d/day%d\n", values[0], values[1])
+	return nil
+}
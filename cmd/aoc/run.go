@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"tea-cats.co.uk/aoc/inputs"
+	"tea-cats.co.uk/aoc/ledger"
+	"tea-cats.co.uk/aoc/runner"
+	"time"
+)
+
+func runRun(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	variants := flags.Bool("variants", false, "run every variant of each part and check they agree")
+	all := flags.Bool("all", false, "run every registered day of the year")
+	parallel := flags.Int("j", 1, "with -all, how many solvers to run at once (memory figures are only accurate with 1)")
+	timeout := flags.Duration("timeout", 0, "how long each solver may run (0 for no limit)")
+	progress := flags.Bool("progress", false, "show progress reported by slow solvers")
+	format := flags.String("format", "text", "with -all, summary format: text, markdown or json")
+	ledgerPath := flags.String("ledger", ledger.Path(), "answer history to check results against (or set "+ledger.PathEnv+")")
+	positional := parseFlags(flags, args)
+
+	// Interrupting a run stops the solvers, which still report how far they got.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *progress {
+		ctx = runner.WithProgress(ctx, progressPrinter())
+	}
+
+	if *all {
+		values, err := parseInts(positional, "year")
+		if err != nil {
+			return err
+		}
+		return runAll(ctx, values[0], *parallel, *timeout, *format, *ledgerPath)
+	}
+
+	parts := []int{1, 2}
+
+	if len(positional) == 3 {
+		values, err := parseInts(positional[2:], "part")
+		if err != nil {
+			return err
+		}
+		parts = values
+		positional = positional[:2]
+	}
+
+	values, err := parseInts(positional, "year", "day")
+	if err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		if *variants {
+			if err := compareVariants(ctx, runner.Puzzle{Year: values[0], Day: values[1], Part: part}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result := runWithTimeout(ctx, runner.Puzzle{Year: values[0], Day: values[1], Part: part}, *timeout)
+		if result.Err != nil && result.Progress != "" {
+			return fmt.Errorf("%s: %w (got as far as: %s)", result.Puzzle, result.Err, result.Progress)
+		}
+		if result.Err != nil {
+			return fmt.Errorf("%s: %w", result.Puzzle, result.Err)
+		}
+
+		fmt.Printf("%s: %s (%s)\n", result.Puzzle, result.AnswerString(), result.Elapsed)
+	}
+
+	return nil
+}
+
+func runWithTimeout(ctx context.Context, puzzle runner.Puzzle, timeout time.Duration) runner.Result {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return runner.Run(ctx, puzzle)
+}
+
+// progressPrinter shows progress reports on stderr, at most once a second
+// for each puzzle.
+func progressPrinter() func(runner.Puzzle, string) {
+	var mutex sync.Mutex
+	printed := make(map[runner.Puzzle]time.Time)
+
+	return func(puzzle runner.Puzzle, message string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if time.Since(printed[puzzle]) < time.Second {
+			return
+		}
+		printed[puzzle] = time.Now()
+
+		fmt.Fprintf(os.Stderr, "%s: %s\n", puzzle, message)
+	}
+}
+
+func compareVariants(ctx context.Context, puzzle runner.Puzzle) error {
+	input, err := inputs.Read(puzzle.Year, puzzle.Day)
+	if err != nil {
+		return err
+	}
+
+	results, err := runner.Compare(ctx, puzzle, input)
+
+	fmt.Printf("%s:\n", puzzle)
+	if err := runner.WriteComparison(os.Stdout, results); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	return err
+}
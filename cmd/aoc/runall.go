@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"tea-cats.co.uk/aoc/ledger"
+	"tea-cats.co.uk/aoc/runner"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	statusPass    = "PASS"
+	statusFail    = "FAIL"
+	statusUnknown = "-"
+)
+
+// summaryRow is one line of the run-all summary, and its JSON form.
+type summaryRow struct {
+	Year     int           `json:"year"`
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   string        `json:"answer,omitempty"`
+	Expected string        `json:"expected,omitempty"`
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Elapsed  time.Duration `json:"elapsed_ns"`
+	Allocs   uint64        `json:"allocs"`
+	Bytes    uint64        `json:"bytes"`
+	PeakHeap uint64        `json:"peak_heap"`
+}
+
+type summary struct {
+	Results  []summaryRow  `json:"results"`
+	Wall     time.Duration `json:"wall_ns"`
+	Elapsed  time.Duration `json:"elapsed_ns"`
+	Allocs   uint64        `json:"allocs"`
+	PeakHeap uint64        `json:"peak_heap"`
+	Passed   int           `json:"passed"`
+	Failed   int           `json:"failed"`
+}
+
+func runAll(ctx context.Context, year, parallel int, timeout time.Duration, format string, ledgerPath string) error {
+	write, ok := map[string]func(io.Writer, summary) error{
+		"text":     writeSummaryText,
+		"markdown": writeSummaryMarkdown,
+		"json":     writeSummaryJSON,
+	}[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	history, err := ledger.Open(ledgerPath)
+	if err != nil {
+		return err
+	}
+
+	puzzles := make([]runner.Puzzle, 0)
+	for _, puzzle := range runner.Puzzles() {
+		if puzzle.Year == year {
+			puzzles = append(puzzles, puzzle)
+		}
+	}
+	if len(puzzles) == 0 {
+		return fmt.Errorf("no solvers registered for %d", year)
+	}
+
+	start := time.Now()
+	results := runner.RunAll(ctx, puzzles, parallel, timeout)
+	total := summarize(results, history, time.Since(start))
+
+	if err := write(os.Stdout, total); err != nil {
+		return err
+	}
+
+	if total.Failed > 0 {
+		return fmt.Errorf("%d of %d failed", total.Failed, len(total.Results))
+	}
+	return nil
+}
+
+// summarize checks each result against the answers in the ledger, and adds
+// up the totals.
+func summarize(results []runner.Result, history *ledger.Ledger, wall time.Duration) summary {
+	total := summary{Wall: wall}
+
+	for _, result := range results {
+		row := summaryRow{
+			Year:     result.Year,
+			Day:      result.Day,
+			Part:     result.Part,
+			Answer:   result.AnswerString(),
+			Status:   statusUnknown,
+			Elapsed:  result.Elapsed,
+			Allocs:   result.Allocs,
+			Bytes:    result.Bytes,
+			PeakHeap: result.PeakHeap,
+		}
+
+		if correct, ok := history.Answer(result.Puzzle); ok {
+			row.Expected = correct.Answer
+		}
+
+		switch {
+		case result.Err != nil:
+			row.Status = statusFail
+			row.Error = result.Err.Error()
+			if result.Progress != "" {
+				row.Error += " (got as far as: " + result.Progress + ")"
+			}
+		case row.Expected != "" && row.Answer == row.Expected:
+			row.Status = statusPass
+		case row.Expected != "":
+			row.Status = statusFail
+		case history.Check(result.Puzzle, row.Answer) != nil:
+			// Already rejected by the site, or outside its bounds
+			row.Status = statusFail
+		}
+
+		switch row.Status {
+		case statusPass:
+			total.Passed++
+		case statusFail:
+			total.Failed++
+		}
+
+		total.Elapsed += row.Elapsed
+		total.Allocs += row.Allocs
+		total.PeakHeap = max(total.PeakHeap, row.PeakHeap)
+		total.Results = append(total.Results, row)
+	}
+
+	return total
+}
+
+// cells are the columns of the text and Markdown tables.
+func (row summaryRow) cells() []string {
+	answer := row.Answer
+	if row.Error != "" {
+		answer = "error: " + row.Error
+	}
+
+	return []string{
+		fmt.Sprintf("%d day %d part %d", row.Year, row.Day, row.Part),
+		answer,
+		row.Status,
+		row.Elapsed.Round(time.Microsecond).String(),
+		fmt.Sprint(row.Allocs),
+		formatBytes(row.PeakHeap),
+	}
+}
+
+func (total summary) cells() []string {
+	return []string{
+		"Total",
+		"",
+		fmt.Sprintf("%d/%d", total.Passed, len(total.Results)),
+		fmt.Sprintf("%s (%s wall)", total.Elapsed.Round(time.Microsecond), total.Wall.Round(time.Microsecond)),
+		fmt.Sprint(total.Allocs),
+		formatBytes(total.PeakHeap),
+	}
+}
+
+var summaryHeadings = []string{"Puzzle", "Answer", "Status", "Time", "Allocs", "Peak heap"}
+
+func writeSummaryText(w io.Writer, total summary) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, strings.Join(summaryHeadings, "\t"))
+	for _, row := range total.Results {
+		_, _ = fmt.Fprintln(table, strings.Join(row.cells(), "\t"))
+	}
+	_, _ = fmt.Fprintln(table, strings.Join(total.cells(), "\t"))
+
+	return table.Flush()
+}
+
+func writeSummaryMarkdown(w io.Writer, total summary) error {
+	line := func(cells []string) string {
+		for i, cell := range cells {
+			cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		return "| " + strings.Join(cells, " | ") + " |\n"
+	}
+
+	var out strings.Builder
+	out.WriteString(line(append([]string(nil), summaryHeadings...)))
+	out.WriteString("|---|---|:-:|--:|--:|--:|\n")
+	for _, row := range total.Results {
+		out.WriteString(line(row.cells()))
+	}
+
+	cells := total.cells()
+	for i, cell := range cells {
+		if cell != "" {
+			cells[i] = "**" + cell + "**"
+		}
+	}
+	out.WriteString(line(cells))
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+func writeSummaryJSON(w io.Writer, total summary) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(total)
+}
+
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	value, suffix := float64(n)/unit, "KiB"
+	for _, next := range []string{"MiB", "GiB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"tea-cats.co.uk/aoc/inputs"
+	"tea-cats.co.uk/aoc/ledger"
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/site"
+	"testing"
+	"time"
+)
+
+// stubYear is where the stub solvers are registered, well away from any
+// real puzzles.
+const stubYear = 1
+
+func init() {
+	answer := func(answer any) runner.SolveFunc {
+		return func(ctx context.Context, input []byte) (any, error) {
+			return answer, nil
+		}
+	}
+
+	runner.Register(stubYear, 1, 1, answer(42))
+	runner.Register(stubYear, 1, 2, answer(7))
+	runner.Register(stubYear, 2, 1, func(ctx context.Context, input []byte) (any, error) {
+		panic("boom")
+	})
+	runner.Register(stubYear, 2, 2, answer("a|b"))
+	runner.Register(stubYear, 3, 1, answer(5))
+}
+
+// stubSummary runs the stub solvers and summarizes them against a ledger
+// which knows some of the answers. The measurements are replaced with fixed
+// ones, so that the tables come out the same every time.
+func stubSummary(t *testing.T) summary {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, day := range []string{"1", "2", "3"} {
+		if err := os.WriteFile(filepath.Join(dir, "1", "input-"+day+".txt"), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := inputs.Dir
+	inputs.Dir = dir
+	t.Cleanup(func() { inputs.Dir = old })
+
+	history := &ledger.Ledger{Entries: []ledger.Entry{
+		{Year: stubYear, Day: 1, Part: 1, Answer: "42", Verdict: site.Correct},
+		{Year: stubYear, Day: 1, Part: 2, Answer: "8", Verdict: site.Correct},
+		{Year: stubYear, Day: 3, Part: 1, Answer: "5", Verdict: site.Incorrect},
+	}}
+
+	var puzzles []runner.Puzzle
+	for _, puzzle := range runner.Puzzles() {
+		if puzzle.Year == stubYear {
+			puzzles = append(puzzles, puzzle)
+		}
+	}
+	results := runner.RunAll(context.Background(), puzzles, 2, time.Second)
+	for i := range results {
+		results[i].Elapsed = time.Duration(i+1) * 1500 * time.Microsecond
+		results[i].Allocs = uint64(i)
+		results[i].PeakHeap = uint64(i) << 20
+	}
+
+	return summarize(results, history, 5*time.Millisecond)
+}
+
+func TestSummarize(t *testing.T) {
+	total := stubSummary(t)
+
+	var statuses []string
+	for _, row := range total.Results {
+		statuses = append(statuses, row.Status)
+	}
+	if want := []string{statusPass, statusFail, statusFail, statusUnknown, statusFail}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("got statuses %v, want %v", statuses, want)
+	}
+	if total.Passed != 1 || total.Failed != 3 {
+		t.Errorf("got %d passed and %d failed, want 1 and 3", total.Passed, total.Failed)
+	}
+	if got := total.Results[2].Error; got != "panic: boom" {
+		t.Errorf("got error %q for the panic", got)
+	}
+	if total.Elapsed != 22500*time.Microsecond || total.Allocs != 10 || total.PeakHeap != 4<<20 {
+		t.Errorf("got totals %s, %d allocs, %d peak heap", total.Elapsed, total.Allocs, total.PeakHeap)
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	total := stubSummary(t)
+
+	tests := []struct {
+		name  string
+		write func(io.Writer, summary) error
+		want  string
+	}{
+		{"text", writeSummaryText, "" +
+			"Puzzle          Answer              Status  Time               Allocs  Peak heap\n" +
+			"1 day 1 part 1  42                  PASS    1.5ms              0       0 B\n" +
+			"1 day 1 part 2  7                   FAIL    3ms                1       1.0 MiB\n" +
+			"1 day 2 part 1  error: panic: boom  FAIL    4.5ms              2       2.0 MiB\n" +
+			"1 day 2 part 2  a|b                 -       6ms                3       3.0 MiB\n" +
+			"1 day 3 part 1  5                   FAIL    7.5ms              4       4.0 MiB\n" +
+			"Total                               1/5     22.5ms (5ms wall)  10      4.0 MiB\n"},
+		{"markdown", writeSummaryMarkdown, "" +
+			"| Puzzle | Answer | Status | Time | Allocs | Peak heap |\n" +
+			"|---|---|:-:|--:|--:|--:|\n" +
+			"| 1 day 1 part 1 | 42 | PASS | 1.5ms | 0 | 0 B |\n" +
+			"| 1 day 1 part 2 | 7 | FAIL | 3ms | 1 | 1.0 MiB |\n" +
+			"| 1 day 2 part 1 | error: panic: boom | FAIL | 4.5ms | 2 | 2.0 MiB |\n" +
+			"| 1 day 2 part 2 | a\\|b | - | 6ms | 3 | 3.0 MiB |\n" +
+			"| 1 day 3 part 1 | 5 | FAIL | 7.5ms | 4 | 4.0 MiB |\n" +
+			"| **Total** |  | **1/5** | **22.5ms (5ms wall)** | **10** | **4.0 MiB** |\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := test.write(&out, total); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.want {
+				t.Errorf("got\n%s\nwant\n%s", out.String(), test.want)
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := writeSummaryJSON(&out, total); err != nil {
+			t.Fatal(err)
+		}
+
+		var got summary
+		if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, total) {
+			t.Errorf("got %+v, want %+v", got, total)
+		}
+		if !bytes.Contains(out.Bytes(), []byte(`"error": "panic: boom"`)) {
+			t.Errorf("no error for the panic in\n%s", out.String())
+		}
+	})
+}
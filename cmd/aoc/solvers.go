@@ -0,0 +1,14 @@
+// Code generated by aoc new; DO NOT EDIT.
+
+package main
+
+import (
+	_ "tea-cats.co.uk/aoc/2024/day14"
+	_ "tea-cats.co.uk/aoc/2024/day16"
+	_ "tea-cats.co.uk/aoc/2024/day17"
+	_ "tea-cats.co.uk/aoc/2024/day23"
+	_ "tea-cats.co.uk/aoc/2024/day24"
+	_ "tea-cats.co.uk/aoc/2024/day6"
+	_ "tea-cats.co.uk/aoc/2024/day7"
+	_ "tea-cats.co.uk/aoc/2024/day9"
+)
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"tea-cats.co.uk/aoc/ledger"
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/site"
+	"time"
+)
+
+func runSubmit(args []string) error {
+	flags := flag.NewFlagSet("submit", flag.ExitOnError)
+	baseURL := flags.String("url", site.DefaultBaseURL, "base URL of the site")
+	ledgerPath := flags.String("ledger", ledger.Path(), "answer history file (or set "+ledger.PathEnv+")")
+	wait := flags.Bool("wait", false, "sleep until the site will accept another answer, rather than giving up")
+	_ = flags.Parse(args)
+
+	positional := flags.Args()
+	if len(positional) != 3 && len(positional) != 4 {
+		return errors.New("expected arguments: year day part [answer]")
+	}
+
+	values, err := parseInts(positional[:3], "year", "day", "part")
+	if err != nil {
+		return err
+	}
+	puzzle := runner.Puzzle{Year: values[0], Day: values[1], Part: values[2]}
+
+	answer := ""
+	if len(positional) == 4 {
+		answer = positional[3]
+	} else {
+		result := runner.Run(context.Background(), puzzle)
+		if result.Err != nil {
+			return fmt.Errorf("%s: %w", puzzle, result.Err)
+		}
+		answer = result.AnswerString()
+		fmt.Printf("%s: computed %s in %s\n", puzzle, answer, result.Elapsed)
+	}
+	if answer == "" {
+		return fmt.Errorf("%s: no answer to submit", puzzle)
+	}
+
+	history, err := ledger.Open(*ledgerPath)
+	if err != nil {
+		return err
+	}
+	if err := history.Check(puzzle, answer); err != nil {
+		return err
+	}
+
+	if until := history.NextSubmission(); time.Now().Before(until) {
+		remaining := time.Until(until).Round(time.Second)
+		if !*wait {
+			return fmt.Errorf("the site asked for a wait, %s remaining (use -wait to sleep until then)", remaining)
+		}
+		fmt.Printf("Waiting %s before submitting\n", remaining)
+		time.Sleep(time.Until(until))
+	}
+
+	session, err := site.LoadSession()
+	if err != nil {
+		return err
+	}
+
+	client := site.NewClient(session)
+	client.BaseURL = *baseURL
+
+	response, err := client.Submit(puzzle.Year, puzzle.Day, puzzle.Part, answer)
+	if err != nil {
+		return err
+	}
+
+	err = history.Record(ledger.Entry{
+		Year:    puzzle.Year,
+		Day:     puzzle.Day,
+		Part:    puzzle.Part,
+		Answer:  answer,
+		Verdict: response.Verdict,
+		Time:    time.Now(),
+		Wait:    response.Wait,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s is %s\n", puzzle, answer, response.Verdict)
+	if response.Message != "" {
+		fmt.Println(response.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"tea-cats.co.uk/aoc/2024/day17/vm"
+	"tea-cats.co.uk/aoc/inputs"
+)
+
+const vmUsage = "expected: vm trace|debug|disasm|compile [flags] [file], or vm asm <file>"
+
+// runVM works with the 3-bit computer from 2024 day 17. Programs are read
+// from the file given, or the day's input by default, except for asm, which
+// reads assembler source.
+func runVM(args []string) error {
+	if len(args) == 0 {
+		return errors.New(vmUsage)
+	}
+
+	if args[0] == "asm" {
+		if len(args) != 2 {
+			return errors.New(vmUsage)
+		}
+
+		source, err := os.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+
+		program, err := vm.Assemble(string(source))
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[1], err)
+		}
+
+		fmt.Print(vm.Format(program))
+		return nil
+	}
+
+	flags := flag.NewFlagSet("vm "+args[0], flag.ExitOnError)
+	a := flags.String("a", "", "value to start register A with (default from the program)")
+	limit := flags.Int("limit", 1_000_000, "most steps to run (0 for no limit)")
+	history := flags.Int("history", 1000, "with debug, how many steps can be undone")
+	pkg := flags.String("package", "main", "with compile, the package to generate")
+	name := flags.String("func", "run", "with compile, the name of the function to generate")
+	positional := parseFlags(flags, args[1:])
+
+	program, err := loadProgram(positional)
+	if err != nil {
+		return err
+	}
+
+	machine := vm.New(program)
+	if *a != "" {
+		if machine.A, err = strconv.ParseUint(*a, 0, 64); err != nil {
+			return fmt.Errorf("invalid value for A: %w", err)
+		}
+	}
+
+	switch args[0] {
+	case "disasm":
+		fmt.Print(vm.Source(program))
+		return nil
+	case "compile":
+		source, err := vm.GoSource(program, *pkg, *name)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(source)
+		return err
+	case "trace":
+		return vm.Trace(os.Stdout, machine, *limit)
+	case "debug":
+		return debugVM(os.Stdin, os.Stdout, vm.NewDebugger(machine, *history), *limit)
+	}
+
+	return errors.New(vmUsage)
+}
+
+func loadProgram(args []string) (vm.Program, error) {
+	switch len(args) {
+	case 0:
+		raw, err := inputs.Read(2024, 17)
+		if err != nil {
+			return vm.Program{}, err
+		}
+		return vm.Parse(raw)
+	case 1:
+		raw, err := os.ReadFile(args[0])
+		if err != nil {
+			return vm.Program{}, err
+		}
+		return vm.Parse(raw)
+	}
+
+	return vm.Program{}, errors.New(vmUsage)
+}
+
+const debugHelp = `commands:
+  s, step [n]       run n instructions (default 1)
+  c, continue       run until a breakpoint or the program halts
+  r, back [n]       undo n instructions (default 1)
+  b, break <bp>     stop at a position (4) or on a register (a==0, b>3, out>=2)
+  d, delete <n>     remove breakpoint n
+  w, watch <reg>    report whenever a register (a, b, c, ip, out, steps) changes
+  p, print          show the registers and output
+  l, list           show the program and breakpoints
+  q, quit
+`
+
+// debugVM is a small command line debugger, reading commands from in.
+// An empty line repeats the last command.
+func debugVM(in io.Reader, out io.Writer, debugger *vm.Debugger, limit int) error {
+	m := debugger.Machine
+	scanner := bufio.NewScanner(in)
+	last := ""
+
+	printState := func() {
+		fmt.Fprintf(out, "ip=%02d a=%d b=%d c=%d output=%s\n", 2*m.IP, m.A, m.B, m.C, vm.FormatOutput(m.Output))
+		if !m.Halted() {
+			fmt.Fprintf(out, "next: %s\n", m.Instructions[m.IP].Explain())
+		} else {
+			fmt.Fprintln(out, "halted")
+		}
+	}
+
+	// count reads an optional repeat count for step and back.
+	count := func(fields []string) (int, error) {
+		if len(fields) < 2 {
+			return 1, nil
+		}
+		return strconv.Atoi(fields[1])
+	}
+
+	fmt.Fprint(out, debugHelp)
+	printState()
+
+	for {
+		fmt.Fprint(out, "(vm) ")
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			line = last
+		}
+		last = line
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		before := m.Snapshot()
+
+		switch fields[0] {
+		case "s", "step":
+			n, err := count(fields)
+			if err != nil {
+				fmt.Fprintf(out, "invalid count: %v\n", err)
+				continue
+			}
+			for range n {
+				record, running, err := debugger.Step()
+				if err != nil {
+					fmt.Fprintln(out, err)
+					break
+				}
+				if !running && record.Op == "" {
+					break
+				}
+				fmt.Fprintf(out, "%02d  %s %d", record.IP, record.Op, record.Operand)
+				if record.Out != nil {
+					fmt.Fprintf(out, "  -> output %d", *record.Out)
+				}
+				fmt.Fprintln(out)
+			}
+			printState()
+
+		case "c", "continue":
+			breakpoint, err := debugger.Continue(limit)
+			switch {
+			case err != nil:
+				fmt.Fprintln(out, err)
+			case breakpoint != nil:
+				fmt.Fprintf(out, "breakpoint %s\n", breakpoint.Text)
+			}
+			printState()
+
+		case "r", "back":
+			n, err := count(fields)
+			if err != nil {
+				fmt.Fprintf(out, "invalid count: %v\n", err)
+				continue
+			}
+			for i := range n {
+				if !debugger.Back() {
+					fmt.Fprintf(out, "no more history after %d steps back\n", i)
+					break
+				}
+			}
+			printState()
+
+		case "b", "break":
+			breakpoint, err := vm.ParseBreakpoint(strings.Join(fields[1:], ""))
+			if err != nil {
+				fmt.Fprintln(out, err)
+				continue
+			}
+			debugger.Breakpoints = append(debugger.Breakpoints, breakpoint)
+			fmt.Fprintf(out, "breakpoint %d: %s\n", len(debugger.Breakpoints), breakpoint.Text)
+
+		case "d", "delete":
+			n, err := count(fields)
+			if err != nil || len(fields) < 2 || n < 1 || n > len(debugger.Breakpoints) {
+				fmt.Fprintln(out, "expected the number of a breakpoint")
+				continue
+			}
+			debugger.Breakpoints = append(debugger.Breakpoints[:n-1], debugger.Breakpoints[n:]...)
+
+		case "w", "watch":
+			if len(fields) < 2 {
+				fmt.Fprintln(out, "expected a register")
+				continue
+			}
+			if err := debugger.Watch(fields[1]); err != nil {
+				fmt.Fprintln(out, err)
+			}
+
+		case "p", "print":
+			printState()
+
+		case "l", "list":
+			for i, inst := range m.Instructions {
+				marker := "  "
+				if i == m.IP {
+					marker = "=>"
+				}
+				fmt.Fprintf(out, "%s %02d  %s\n", marker, 2*i, inst.Explain())
+			}
+			for i, breakpoint := range debugger.Breakpoints {
+				fmt.Fprintf(out, "breakpoint %d: %s\n", i+1, breakpoint.Text)
+			}
+
+		case "q", "quit":
+			return nil
+
+		default:
+			fmt.Fprint(out, debugHelp)
+		}
+
+		for _, change := range debugger.Changes(before) {
+			fmt.Fprintf(out, "watch %s\n", change)
+		}
+	}
+}
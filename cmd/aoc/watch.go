@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"tea-cats.co.uk/aoc/inputs"
+	"time"
+)
+
+func runWatch(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	interval := flags.Duration("interval", 250*time.Millisecond, "how often to check for changes")
+	debounce := flags.Duration("debounce", 300*time.Millisecond, "how long files must be left alone before re-running")
+	timeout := flags.Duration("timeout", time.Minute, "how long each part may run")
+	positional := parseFlags(flags, args)
+
+	values, err := parseInts(positional, "year", "day")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(fmt.Sprint(values[0]), fmt.Sprintf("day%d", values[1]))
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	build, err := os.MkdirTemp("", "aoc-watch-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(build)
+
+	w := watcher{
+		dir:     dir,
+		files:   []string{inputs.Path(values[0], values[1]), inputs.EncryptedPath(values[0], values[1])},
+		build:   build,
+		timeout: *timeout,
+		answers: make(map[string]string),
+	}
+
+	fmt.Printf("Watching %s and its input, press Ctrl-C to stop\n", dir)
+
+	snapshot := w.snapshot()
+	w.run(ctx, nil)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(*interval):
+		}
+
+		current := w.snapshot()
+		if maps.Equal(current, snapshot) {
+			continue
+		}
+
+		// Editors often write a file several times when saving, so wait for
+		// things to settle down before rebuilding.
+		for settled := time.Now(); time.Since(settled) < *debounce; {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(*interval):
+			}
+
+			if next := w.snapshot(); !maps.Equal(next, current) {
+				current = next
+				settled = time.Now()
+			}
+		}
+
+		changed := changedFiles(snapshot, current)
+		snapshot = current
+		w.run(ctx, changed)
+	}
+}
+
+// stamp is enough of a file's metadata to notice that it has been changed.
+type stamp struct {
+	modified time.Time
+	size     int64
+}
+
+type watcher struct {
+	dir     string
+	files   []string
+	build   string
+	timeout time.Duration
+
+	// answers is what each part printed on the previous run.
+	answers map[string]string
+}
+
+// snapshot stamps every file in the day's directory, and the input files.
+// Files which do not exist are left out, so creating them counts as a change.
+func (w *watcher) snapshot() map[string]stamp {
+	stamps := make(map[string]stamp)
+
+	add := func(path string, info fs.FileInfo) {
+		stamps[path] = stamp{modified: info.ModTime(), size: info.Size()}
+	}
+
+	_ = filepath.WalkDir(w.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			add(path, info)
+		}
+		return nil
+	})
+
+	for _, path := range w.files {
+		if info, err := os.Stat(path); err == nil {
+			add(path, info)
+		}
+	}
+
+	return stamps
+}
+
+// changedFiles lists the files which were created, changed or deleted
+// between two snapshots, in order.
+func changedFiles(before, after map[string]stamp) []string {
+	changed := make([]string, 0)
+
+	for path, s := range after {
+		if previous, ok := before[path]; !ok || previous != s {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	slices.Sort(changed)
+	return changed
+}
+
+// run rebuilds and runs every part of the day, then its example tests.
+func (w *watcher) run(ctx context.Context, changed []string) {
+	fmt.Printf("\n=== %s", time.Now().Format(time.TimeOnly))
+	if len(changed) > 0 {
+		fmt.Printf(" (changed: %s)", strings.Join(changed, ", "))
+	}
+	fmt.Println()
+
+	parts, err := filepath.Glob(filepath.Join(w.dir, "part*", "main.go"))
+	if err != nil || len(parts) == 0 {
+		fmt.Printf("No parts found in %s\n", w.dir)
+		return
+	}
+
+	for _, main := range parts {
+		part := filepath.Base(filepath.Dir(main))
+
+		answer, err := w.runPart(ctx, filepath.Dir(main))
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			fmt.Printf("%s: %v\n", part, err)
+			continue
+		}
+
+		previous, seen := w.answers[part]
+		w.answers[part] = answer
+
+		switch {
+		case !seen:
+			fmt.Printf("%s: %s\n", part, answer)
+		case previous == answer:
+			fmt.Printf("%s: %s (unchanged)\n", part, answer)
+		default:
+			fmt.Printf("%s: changed\n", part)
+			printDiff(os.Stdout, previous, answer)
+		}
+	}
+
+	w.runExamples(ctx)
+}
+
+// runPart builds the main package for one part and runs it, returning what
+// it printed. The timings the solvers log go to stderr, so are left out.
+func (w *watcher) runPart(ctx context.Context, pkg string) (string, error) {
+	binary := filepath.Join(w.build, filepath.Base(pkg))
+
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, "./"+filepath.ToSlash(pkg))
+	if output, err := build.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("build failed:\n%s", indent(output))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	run := exec.CommandContext(ctx, binary)
+	run.Stdout = &stdout
+	run.Stderr = &stderr
+
+	if err := run.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("timed out after %s", w.timeout)
+		}
+		return "", fmt.Errorf("%w:\n%s", err, indent(stderr.Bytes()))
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(stdout.String(), "Result: ")), nil
+}
+
+// testEvent is the subset of the output of go test -json which is needed.
+type testEvent struct {
+	Action string
+	Test   string
+	Output string
+}
+
+// runExamples runs the day's example tests, and reports how each one went.
+func (w *watcher) runExamples(ctx context.Context) {
+	tests, _ := filepath.Glob(filepath.Join(w.dir, "*_test.go"))
+	if len(tests) == 0 {
+		fmt.Println("examples: no tests")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	test := exec.CommandContext(ctx, "go", "test", "-json", "-count=1", "-run", "^TestExamples$", "./"+filepath.ToSlash(w.dir))
+	test.Stdout = &stdout
+	test.Stderr = &stderr
+	err := test.Run()
+
+	results, failure := exampleResults(&stdout)
+
+	if len(results) == 0 && err != nil {
+		// Older versions of go test report build failures on stderr
+		failure = append(failure, stderr.Bytes()...)
+		fmt.Printf("examples: %v\n%s\n", err, indent(failure))
+		return
+	}
+
+	fmt.Println("examples:")
+	for _, result := range results {
+		fmt.Printf("  %s\n", result)
+	}
+}
+
+// exampleResults reads the events from go test -json, returning a line for
+// each example saying how it went, and the output of a failed build.
+func exampleResults(events io.Reader) (results []string, failure []byte) {
+	output := make(map[string][]string)
+	results = make([]string, 0)
+
+	scanner := bufio.NewScanner(events)
+	for scanner.Scan() {
+		event := testEvent{}
+		if json.Unmarshal(scanner.Bytes(), &event) != nil {
+			continue
+		}
+		if event.Action == "build-output" {
+			failure = append(failure, event.Output...)
+			continue
+		}
+		if !strings.HasPrefix(event.Test, "TestExamples/") {
+			continue
+		}
+
+		name := strings.TrimPrefix(event.Test, "TestExamples/")
+
+		switch event.Action {
+		case "output":
+			// Only keep what the test itself said, not the runner's own lines
+			if line := strings.TrimSpace(event.Output); strings.Contains(line, ".go:") {
+				output[name] = append(output[name], line)
+			}
+		case "pass":
+			results = append(results, "PASS "+name)
+		case "skip":
+			results = append(results, "SKIP "+name)
+		case "fail":
+			results = append(results, "FAIL "+name)
+			for _, line := range output[name] {
+				results = append(results, "    "+line)
+			}
+		}
+	}
+
+	return results, failure
+}
+
+// printDiff shows the lines which differ between two runs of a part.
+func printDiff(w io.Writer, before, after string) {
+	old := strings.Split(before, "\n")
+	current := strings.Split(after, "\n")
+
+	for i := range max(len(old), len(current)) {
+		switch {
+		case i >= len(old):
+			fmt.Fprintf(w, "  + %s\n", current[i])
+		case i >= len(current):
+			fmt.Fprintf(w, "  - %s\n", old[i])
+		case old[i] != current[i]:
+			fmt.Fprintf(w, "  - %s\n  + %s\n", old[i], current[i])
+		}
+	}
+}
+
+func indent(output []byte) string {
+	lines := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+	return "    " + strings.Join(lines, "\n    ")
+}
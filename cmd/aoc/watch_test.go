@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChangedFiles(t *testing.T) {
+	now := time.Date(2024, 12, 1, 5, 0, 0, 0, time.UTC)
+	before := map[string]stamp{
+		"2024/day1/load.go":       {modified: now, size: 100},
+		"2024/day1/part1/main.go": {modified: now, size: 200},
+		"inputs/2024/input-1.txt": {modified: now, size: 300},
+	}
+
+	tests := []struct {
+		name  string
+		after map[string]stamp
+		want  []string
+	}{
+		{
+			name:  "unchanged",
+			after: before,
+			want:  []string{},
+		},
+		{
+			name: "touched",
+			after: map[string]stamp{
+				"2024/day1/load.go":       {modified: now.Add(time.Second), size: 100},
+				"2024/day1/part1/main.go": {modified: now, size: 200},
+				"inputs/2024/input-1.txt": {modified: now, size: 300},
+			},
+			want: []string{"2024/day1/load.go"},
+		},
+		{
+			name: "resized",
+			after: map[string]stamp{
+				"2024/day1/load.go":       {modified: now, size: 100},
+				"2024/day1/part1/main.go": {modified: now, size: 201},
+				"inputs/2024/input-1.txt": {modified: now, size: 300},
+			},
+			want: []string{"2024/day1/part1/main.go"},
+		},
+		{
+			name: "created and deleted",
+			after: map[string]stamp{
+				"2024/day1/load.go":       {modified: now, size: 100},
+				"2024/day1/part2/main.go": {modified: now, size: 50},
+				"inputs/2024/input-1.txt": {modified: now, size: 300},
+			},
+			want: []string{"2024/day1/part1/main.go", "2024/day1/part2/main.go"},
+		},
+		{
+			name:  "everything deleted",
+			after: map[string]stamp{},
+			want:  []string{"2024/day1/load.go", "2024/day1/part1/main.go", "inputs/2024/input-1.txt"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := changedFiles(before, test.after); !slices.Equal(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPrintDiff(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after string
+		want          string
+	}{
+		{"same", "1\n2", "1\n2", ""},
+		{"changed", "1\n2\n3", "1\n5\n3", "  - 2\n  + 5\n"},
+		{"longer", "1", "1\n2", "  + 2\n"},
+		{"shorter", "1\n2", "1", "  - 2\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			printDiff(&out, test.before, test.after)
+			if out.String() != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", out.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestExampleResults(t *testing.T) {
+	events := strings.Join([]string{
+		`{"Action":"run","Test":"TestExamples"}`,
+		`{"Action":"run","Test":"TestExamples/part1"}`,
+		`{"Action":"output","Test":"TestExamples/part1","Output":"=== RUN   TestExamples/part1\n"}`,
+		`{"Action":"pass","Test":"TestExamples/part1"}`,
+		`{"Action":"run","Test":"TestExamples/part2"}`,
+		`{"Action":"output","Test":"TestExamples/part2","Output":"    examples.go:40: got 5, want 6\n"}`,
+		`{"Action":"output","Test":"TestExamples/part2","Output":"--- FAIL: TestExamples/part2 (0.00s)\n"}`,
+		`{"Action":"fail","Test":"TestExamples/part2"}`,
+		`{"Action":"skip","Test":"TestExamples/part3"}`,
+		`not json`,
+		`{"Action":"pass","Test":"TestOther"}`,
+		`{"Action":"fail","Test":"TestExamples"}`,
+	}, "\n")
+
+	results, failure := exampleResults(strings.NewReader(events))
+
+	want := []string{
+		"PASS part1",
+		"FAIL part2",
+		"    examples.go:40: got 5, want 6",
+		"SKIP part3",
+	}
+	if !slices.Equal(results, want) {
+		t.Errorf("got %q, want %q", results, want)
+	}
+	if len(failure) != 0 {
+		t.Errorf("got build output %q", failure)
+	}
+}
+
+func TestExampleResultsBuildFailure(t *testing.T) {
+	events := strings.Join([]string{
+		`{"ImportPath":"x","Action":"build-output","Output":"# x\n"}`,
+		`{"ImportPath":"x","Action":"build-output","Output":"load.go:3:1: syntax error\n"}`,
+		`{"ImportPath":"x","Action":"build-fail"}`,
+	}, "\n")
+
+	results, failure := exampleResults(strings.NewReader(events))
+
+	if len(results) != 0 {
+		t.Errorf("got results %q", results)
+	}
+	if want := "# x\nload.go:3:1: syntax error\n"; string(failure) != want {
+		t.Errorf("got build output %q, want %q", failure, want)
+	}
+}
@@ -0,0 +1,134 @@
+// Package examples pulls the worked examples out of a saved puzzle page, so
+// that they can be used as test data.
+package examples
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Block is the contents of one <pre><code> block from the puzzle text.
+type Block struct {
+	Part int
+	Text string
+}
+
+// Proposal pairs an example block with the highlighted answer that the
+// puzzle text gives for it.
+type Proposal struct {
+	Part   int
+	Block  int
+	Answer string
+}
+
+type Page struct {
+	Blocks    []Block
+	Proposals []Proposal
+}
+
+var ErrNoPuzzle = errors.New("no puzzle description found in page")
+
+// Parse reads the puzzle descriptions from a saved page. Each part of the
+// puzzle is an <article>; its answer to the example is taken to be the last
+// <code><em> in it, and the example the last block before that answer. Part
+// two often reuses the example from part one, so if it has no blocks of its
+// own, the previous part's example is used.
+func Parse(page []byte) (Page, error) {
+	// Only look at the puzzle text; the rest of the page has scripts that
+	// the tokeniser is not going to enjoy.
+	start := bytes.Index(page, []byte("<article"))
+	end := bytes.LastIndex(page, []byte("</article>"))
+	if start < 0 || end < start {
+		return Page{}, ErrNoPuzzle
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(page[start : end+len("</article>")]))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	result := Page{}
+	part := 0
+	var inPre, inCode, inEm int
+	var text, highlighted strings.Builder
+	answer, answerBlock, lastBlock := "", -1, -1
+
+	finishPart := func() {
+		if part == 0 || answer == "" {
+			return
+		}
+		block := answerBlock
+		if block < 0 {
+			block = lastBlock
+		}
+		if block >= 0 {
+			result.Proposals = append(result.Proposals, Proposal{Part: part, Block: block, Answer: answer})
+		}
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch strings.ToLower(t.Name.Local) {
+			case "article":
+				finishPart()
+				part++
+				answer, answerBlock = "", -1
+			case "pre":
+				inPre++
+				text.Reset()
+			case "code":
+				inCode++
+			case "em":
+				inEm++
+				highlighted.Reset()
+			}
+
+		case xml.EndElement:
+			switch strings.ToLower(t.Name.Local) {
+			case "pre":
+				inPre--
+				if inPre == 0 && text.Len() > 0 {
+					result.Blocks = append(result.Blocks, Block{Part: part, Text: text.String()})
+					lastBlock = len(result.Blocks) - 1
+				}
+			case "code":
+				inCode--
+			case "em":
+				inEm--
+				if inPre == 0 && inCode > 0 && highlighted.Len() > 0 {
+					answer, answerBlock = strings.TrimSpace(highlighted.String()), -1
+					if lastBlock >= 0 && result.Blocks[lastBlock].Part == part {
+						answerBlock = lastBlock
+					}
+				}
+			}
+
+		case xml.CharData:
+			if inPre > 0 && inCode > 0 {
+				text.Write(t)
+			} else if inCode > 0 && inEm > 0 {
+				highlighted.Write(t)
+			}
+		}
+	}
+
+	finishPart()
+
+	if part == 0 {
+		return result, ErrNoPuzzle
+	}
+
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package examples
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+const day1Example = "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		file string
+		want Page
+	}{
+		{
+			file: "day1.html",
+			want: Page{
+				Blocks:    []Block{{Part: 1, Text: day1Example}},
+				Proposals: []Proposal{{Part: 1, Block: 0, Answer: "11"}},
+			},
+		},
+		{
+			// Part two has no example of its own, so reuses part one's.
+			file: "day1-part2.html",
+			want: Page{
+				Blocks: []Block{{Part: 1, Text: day1Example}},
+				Proposals: []Proposal{
+					{Part: 1, Block: 0, Answer: "11"},
+					{Part: 2, Block: 0, Answer: "31"},
+				},
+			},
+		},
+		{
+			// Several blocks in part one, where the answer goes with the
+			// last, and a new example for part two.
+			file: "day3-part2.html",
+			want: Page{
+				Blocks: []Block{
+					{Part: 1, Text: "mul(4*\nmul(6,9!\n?(12,34)\nmul ( 2 , 4 )\n"},
+					{Part: 1, Text: "xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))"},
+					{Part: 2, Text: "xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))"},
+				},
+				Proposals: []Proposal{
+					{Part: 1, Block: 1, Answer: "161"},
+					{Part: 2, Block: 2, Answer: "48"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			raw, err := os.ReadFile("testdata/" + test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Parse(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %#v\nwant %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseNoPuzzle(t *testing.T) {
+	for _, page := range []string{
+		"",
+		"<html><body><p>Please log in.</p></body></html>",
+		"</article><article>",
+	} {
+		if _, err := Parse([]byte(page)); !errors.Is(err, ErrNoPuzzle) {
+			t.Errorf("Parse(%q) = %v, want ErrNoPuzzle", page, err)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package inputs
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EncryptedSuffix is added to the name of an input file once encrypted.
+const EncryptedSuffix = ".enc"
+
+const (
+	// PassphraseEnv holds the passphrase to derive keys from.
+	PassphraseEnv = "AOC_INPUT_PASSPHRASE"
+	// KeyFileEnv points at a file of random bytes to derive keys from.
+	KeyFileEnv = "AOC_INPUT_KEY_FILE"
+)
+
+// Passphrases are stretched; key files are assumed to be random already.
+const (
+	passphraseIterations = 200_000
+	keyFileIterations    = 1
+)
+
+var (
+	ErrNoSecret     = errors.New("no key for encrypted inputs: set " + PassphraseEnv + " or " + KeyFileEnv + ", or create " + defaultKeyFileDescription)
+	ErrNotEncrypted = errors.New("not an encrypted input file")
+)
+
+const defaultKeyFileDescription = "$XDG_CONFIG_HOME/aoc/input.key"
+
+// Encrypted files are laid out as:
+//
+//	magic | iterations (uint32) | salt | nonce | AES-GCM ciphertext
+//
+// with the plain text file's year and name as additional data, so that one
+// day's input cannot be swapped in for another's, nor one year's for
+// another's.
+var magic = []byte("AOC-ENC2")
+
+const saltSize = 16
+
+// Secret is the passphrase or key file that per-file keys are derived from.
+type Secret struct {
+	value      []byte
+	iterations int
+}
+
+func PassphraseSecret(passphrase string) Secret {
+	return Secret{value: []byte(passphrase), iterations: passphraseIterations}
+}
+
+func KeyFileSecret(contents []byte) Secret {
+	return Secret{value: bytes.TrimSpace(contents), iterations: keyFileIterations}
+}
+
+// DefaultKeyFile is used when neither environment variable is set.
+func DefaultKeyFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc", "input.key"), nil
+}
+
+// LoadSecret finds the secret from the environment, then the default key file.
+func LoadSecret() (Secret, error) {
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		return PassphraseSecret(passphrase), nil
+	}
+
+	path := os.Getenv(KeyFileEnv)
+	if path == "" {
+		var err error
+		if path, err = DefaultKeyFile(); err != nil {
+			return Secret{}, err
+		}
+	}
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Secret{}, ErrNoSecret
+	}
+	if err != nil {
+		return Secret{}, err
+	}
+	if len(bytes.TrimSpace(contents)) == 0 {
+		return Secret{}, fmt.Errorf("%s: key file is empty", path)
+	}
+
+	return KeyFileSecret(contents), nil
+}
+
+// fileName is what an input file is bound to when encrypted: the name of its
+// year's directory and its own, like 2024/input-10.txt.
+func fileName(path string) string {
+	return filepath.Base(filepath.Dir(path)) + "/" + filepath.Base(path)
+}
+
+// Encrypt seals plain for the input file with the given name, as made by
+// fileName.
+func Encrypt(secret Secret, name string, plain []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(secret.value, salt, secret.iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append([]byte{}, magic...)
+	out = binary.BigEndian.AppendUint32(out, uint32(secret.iterations))
+	out = append(out, salt...)
+	out = append(out, nonce...)
+
+	return aead.Seal(out, nonce, plain, []byte(name)), nil
+}
+
+// Decrypt opens data from the input file with the given name, as made by
+// fileName.
+func Decrypt(secret Secret, name string, data []byte) ([]byte, error) {
+	header := len(magic) + 4 + saltSize
+	if len(data) < header {
+		return nil, ErrNotEncrypted
+	}
+
+	if !bytes.Equal(data[:len(magic)], magic) {
+		return nil, ErrNotEncrypted
+	}
+
+	// The iterations come from the file, so that files locked with a key
+	// file and with a passphrase can be told apart.
+	iterations := int(binary.BigEndian.Uint32(data[len(magic):]))
+	salt := data[len(magic)+4 : header]
+
+	if iterations != secret.iterations {
+		return nil, fmt.Errorf("%s was encrypted with a different kind of key", name)
+	}
+
+	aead, err := newAEAD(secret.value, salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < header+aead.NonceSize() {
+		return nil, ErrNotEncrypted
+	}
+	nonce := data[header : header+aead.NonceSize()]
+
+	plain, err := aead.Open(nil, nonce, data[header+aead.NonceSize():], []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting %s: wrong key or corrupted file", name)
+	}
+
+	return plain, nil
+}
+
+func newAEAD(secret, salt []byte, iterations int) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2(secret, salt, iterations, 32))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2 is PBKDF2-HMAC-SHA256, as described in RFC 8018.
+func pbkdf2(password, salt []byte, iterations, keyLength int) []byte {
+	mac := hmac.New(sha256.New, password)
+	key := make([]byte, 0, keyLength)
+
+	for block := uint32(1); len(key) < keyLength; block++ {
+		mac.Reset()
+		mac.Write(salt)
+		mac.Write(binary.BigEndian.AppendUint32(nil, block))
+		u := mac.Sum(nil)
+		t := append([]byte{}, u...)
+
+		for i := 1; i < iterations; i++ {
+			mac.Reset()
+			mac.Write(u)
+			u = mac.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+
+		key = append(key, t...)
+	}
+
+	return key[:keyLength]
+}
+
+// LockFile encrypts the plain text input at path alongside it. Unless keep is
+// set, the plain text is removed once the encrypted copy is written.
+func LockFile(secret Secret, path string, keep bool) error {
+	plain, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := Encrypt(secret, fileName(path), plain)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path+EncryptedSuffix, encrypted, 0644); err != nil {
+		return err
+	}
+
+	if keep {
+		return nil
+	}
+	return os.Remove(path)
+}
+
+// UnlockFile decrypts the input at path, which must end in EncryptedSuffix,
+// writing the plain text next to it. The encrypted file is left in place.
+func UnlockFile(secret Secret, path string) error {
+	if !strings.HasSuffix(path, EncryptedSuffix) {
+		return fmt.Errorf("%s: %w", path, ErrNotEncrypted)
+	}
+
+	encrypted, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	plainPath := strings.TrimSuffix(path, EncryptedSuffix)
+	plain, err := Decrypt(secret, fileName(plainPath), encrypted)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(plainPath, plain, 0600)
+}
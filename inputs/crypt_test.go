@@ -0,0 +1,160 @@
+package inputs
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestPBKDF2 uses the PBKDF2-HMAC-SHA256 test vectors from RFC 7914, section
+// 11, and the ones commonly given alongside RFC 6070's SHA-1 vectors.
+func TestPBKDF2(t *testing.T) {
+	tests := []struct {
+		password, salt string
+		iterations     int
+		want           string
+	}{
+		{"passwd", "salt", 1, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"Password", "NaCl", 80000, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+		{"password", "salt", 1, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 2, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+		{"password", "salt", 4096, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, "348c89dbcbd32b2f32d814b8116e84cf2b17347ebc1800181c4e2a1fb8dd53e1c635518c7dac47e9"},
+		{"pass\x00word", "sa\x00lt", 4096, "89b69d0516f829893c696226650a8687"},
+	}
+
+	for _, test := range tests {
+		want, err := hex.DecodeString(test.want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := pbkdf2([]byte(test.password), []byte(test.salt), test.iterations, len(want))
+		if !bytes.Equal(got, want) {
+			t.Errorf("pbkdf2(%q, %q, %d) = %x, want %x", test.password, test.salt, test.iterations, got, want)
+		}
+	}
+}
+
+var testKey = KeyFileSecret([]byte("0123456789abcdef0123456789abcdef\n"))
+
+func TestEncryptRoundTrip(t *testing.T) {
+	plain := []byte("1 2 3\n4 5 6\n")
+
+	for name, secret := range map[string]Secret{"key file": testKey, "passphrase": PassphraseSecret("correct horse")} {
+		t.Run(name, func(t *testing.T) {
+			encrypted, err := Encrypt(secret, "2024/input-1.txt", plain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Contains(encrypted, plain) {
+				t.Error("the plain text is in the encrypted file")
+			}
+
+			decrypted, err := Decrypt(secret, "2024/input-1.txt", encrypted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(decrypted, plain) {
+				t.Errorf("got %q, want %q", decrypted, plain)
+			}
+
+			// A fresh salt and nonce each time.
+			again, err := Encrypt(secret, "2024/input-1.txt", plain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Equal(again, encrypted) {
+				t.Error("encrypting twice gave the same output")
+			}
+		})
+	}
+}
+
+func TestDecryptWrongSecret(t *testing.T) {
+	encrypted, err := Encrypt(PassphraseSecret("correct horse"), "2024/input-1.txt", []byte("input"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Decrypt(PassphraseSecret("battery staple"), "2024/input-1.txt", encrypted); err == nil {
+		t.Error("decrypted with the wrong passphrase")
+	}
+	// A key file is a different kind of secret, even with the same bytes.
+	if _, err := Decrypt(KeyFileSecret([]byte("correct horse")), "2024/input-1.txt", encrypted); err == nil {
+		t.Error("decrypted with a key file")
+	}
+}
+
+func TestDecryptTampered(t *testing.T) {
+	plain := []byte("1 2 3\n")
+	encrypted, err := Encrypt(testKey, "2024/input-1.txt", plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := len(magic) + 4 + saltSize
+	for name, offset := range map[string]int{
+		"magic":      0,
+		"iterations": len(magic) + 3,
+		"salt":       len(magic) + 4,
+		"nonce":      header,
+		"ciphertext": header + 12,
+		"tag":        len(encrypted) - 1,
+	} {
+		tampered := bytes.Clone(encrypted)
+		tampered[offset] ^= 1
+		if _, err := Decrypt(testKey, "2024/input-1.txt", tampered); err == nil {
+			t.Errorf("decrypted with the %s changed", name)
+		}
+	}
+
+	for _, length := range []int{0, header - 1, header + 5, len(encrypted) - 1} {
+		if _, err := Decrypt(testKey, "2024/input-1.txt", encrypted[:length]); err == nil {
+			t.Errorf("decrypted the first %d bytes", length)
+		}
+	}
+
+	// The file is bound to its day and year.
+	for _, name := range []string{"2024/input-2.txt", "2023/input-1.txt", "input-1.txt"} {
+		if _, err := Decrypt(testKey, name, encrypted); err == nil {
+			t.Errorf("decrypted as %s", name)
+		}
+	}
+}
+
+func TestLockFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "2024")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "input-3.txt")
+	if err := os.WriteFile(path, []byte("input"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LockFile(testKey, path, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Error("the plain text was kept")
+	}
+
+	// Moving it to another year or day breaks it.
+	encrypted, err := os.ReadFile(path + EncryptedSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(testKey, "2023/input-3.txt", encrypted); err == nil {
+		t.Error("decrypted as another year's")
+	}
+
+	if err := UnlockFile(testKey, path+EncryptedSuffix); err != nil {
+		t.Fatal(err)
+	}
+	if plain, err := os.ReadFile(path); err != nil || string(plain) != "input" {
+		t.Errorf("got %q, %v", plain, err)
+	}
+}
@@ -0,0 +1,76 @@
+// Package inputs finds puzzle inputs on disk, decrypting them if they are
+// only stored in encrypted form.
+package inputs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DirEnv overrides Dir, for keeping inputs outside the repository.
+const DirEnv = "AOC_INPUT_DIR"
+
+// Dir is the directory holding each year's inputs. By default this is the
+// root of the repository, giving paths like 2024/input-10.txt.
+var Dir = dirFromEnv()
+
+func dirFromEnv() string {
+	if dir := os.Getenv(DirEnv); dir != "" {
+		return dir
+	}
+	return "."
+}
+
+// Path is where the plain text input for a day is kept.
+func Path(year, day int) string {
+	return filepath.Join(Dir, fmt.Sprint(year), fmt.Sprintf("input-%d.txt", day))
+}
+
+// EncryptedPath is where the encrypted input for a day is kept.
+func EncryptedPath(year, day int) string {
+	return Path(year, day) + EncryptedSuffix
+}
+
+// PuzzlePath is where a saved copy of the puzzle page for a day is kept.
+func PuzzlePath(year, day int) string {
+	return filepath.Join(Dir, fmt.Sprint(year), fmt.Sprintf("puzzle-%d.html", day))
+}
+
+// Read returns the input for a day, preferring the plain text file and
+// falling back to decrypting the encrypted one in memory.
+func Read(year, day int) ([]byte, error) {
+	data, err := os.ReadFile(Path(year, day))
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return data, err
+	}
+
+	encrypted, encErr := os.ReadFile(EncryptedPath(year, day))
+	if errors.Is(encErr, fs.ErrNotExist) {
+		// Report the file people expect to find.
+		return nil, err
+	}
+	if encErr != nil {
+		return nil, encErr
+	}
+
+	secret, err := LoadSecret()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", EncryptedPath(year, day), err)
+	}
+
+	return Decrypt(secret, fileName(Path(year, day)), encrypted)
+}
+
+// Open is Read for code that wants to treat the input as a file.
+func Open(year, day int) (io.ReadCloser, error) {
+	data, err := Read(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
@@ -0,0 +1,193 @@
+// Package leaderboard reads the JSON export of a private leaderboard and
+// works out star times, local scores and ranks from it.
+package leaderboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Star is one member completing one part of one day.
+type Star struct {
+	Time  time.Time
+	Index int64
+}
+
+type Member struct {
+	ID         int64
+	Name       string
+	Stars      int
+	LocalScore int
+	// Completed holds the stars for each day, indexed by part-1.
+	Completed map[int][2]*Star
+}
+
+type Board struct {
+	Event   int
+	Members []Member
+}
+
+// Unlock is when a day's puzzle is released: midnight in US Eastern time.
+func Unlock(year, day int) time.Time {
+	return time.Date(year, time.December, day, 5, 0, 0, 0, time.UTC)
+}
+
+type exportStar struct {
+	GetStarTs int64 `json:"get_star_ts"`
+	StarIndex int64 `json:"star_index"`
+}
+
+type exportMember struct {
+	ID                 int64                            `json:"id"`
+	Name               *string                          `json:"name"`
+	Stars              int                              `json:"stars"`
+	LocalScore         int                              `json:"local_score"`
+	CompletionDayLevel map[string]map[string]exportStar `json:"completion_day_level"`
+}
+
+type export struct {
+	Event   string                  `json:"event"`
+	Members map[string]exportMember `json:"members"`
+}
+
+func Parse(data []byte) (Board, error) {
+	var raw export
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Board{}, err
+	}
+
+	event, err := strconv.Atoi(raw.Event)
+	if err != nil {
+		return Board{}, fmt.Errorf("invalid event %q", raw.Event)
+	}
+
+	board := Board{Event: event}
+
+	for _, m := range raw.Members {
+		member := Member{
+			ID:         m.ID,
+			Name:       fmt.Sprintf("(anonymous user #%d)", m.ID),
+			Stars:      m.Stars,
+			LocalScore: m.LocalScore,
+			Completed:  make(map[int][2]*Star),
+		}
+		if m.Name != nil {
+			member.Name = *m.Name
+		}
+
+		for dayKey, parts := range m.CompletionDayLevel {
+			day, err := strconv.Atoi(dayKey)
+			if err != nil {
+				return Board{}, fmt.Errorf("member %d: invalid day %q", m.ID, dayKey)
+			}
+
+			stars := [2]*Star{}
+			for partKey, star := range parts {
+				part, err := strconv.Atoi(partKey)
+				if err != nil || part < 1 || part > 2 {
+					return Board{}, fmt.Errorf("member %d day %d: invalid part %q", m.ID, day, partKey)
+				}
+				stars[part-1] = &Star{Time: time.Unix(star.GetStarTs, 0).UTC(), Index: star.StarIndex}
+			}
+			member.Completed[day] = stars
+		}
+
+		board.Members = append(board.Members, member)
+	}
+
+	slices.SortFunc(board.Members, func(a, b Member) int {
+		if a.LocalScore != b.LocalScore {
+			return b.LocalScore - a.LocalScore
+		}
+		return int(a.ID - b.ID)
+	})
+
+	return board, nil
+}
+
+// Days is the highest day any member has a star for.
+func (b Board) Days() int {
+	days := 0
+	for _, member := range b.Members {
+		for day := range member.Completed {
+			days = max(days, day)
+		}
+	}
+	return days
+}
+
+// SolveTime is how long after the puzzle unlocked the member got the star.
+func (b Board) SolveTime(member Member, day, part int) (time.Duration, bool) {
+	star := member.Completed[day][part-1]
+	if star == nil {
+		return 0, false
+	}
+	return star.Time.Sub(Unlock(b.Event, day)), true
+}
+
+// Scores is the local score for each member (in the order of b.Members) once
+// all the stars up to and including each day are counted. The first member
+// to get a star scores one point per member, the next one fewer, and so on.
+func (b Board) Scores() [][]int {
+	days := b.Days()
+	scores := make([][]int, len(b.Members))
+	for i := range scores {
+		scores[i] = make([]int, days+1)
+	}
+
+	for day := 1; day <= days; day++ {
+		for i := range scores {
+			scores[i][day] = scores[i][day-1]
+		}
+
+		for part := 0; part < 2; part++ {
+			finishers := make([]int, 0, len(b.Members))
+			for i, member := range b.Members {
+				if member.Completed[day][part] != nil {
+					finishers = append(finishers, i)
+				}
+			}
+
+			slices.SortFunc(finishers, func(x, y int) int {
+				starX, starY := b.Members[x].Completed[day][part], b.Members[y].Completed[day][part]
+				if c := starX.Time.Compare(starY.Time); c != 0 {
+					return c
+				}
+				return int(starX.Index - starY.Index)
+			})
+
+			for position, i := range finishers {
+				scores[i][day] += len(b.Members) - position
+			}
+		}
+	}
+
+	return scores
+}
+
+// Ranks converts Scores into positions on the board after each day, where
+// 1 is the leader. Members with the same score share a rank.
+func (b Board) Ranks() [][]int {
+	scores := b.Scores()
+	ranks := make([][]int, len(scores))
+	for i := range ranks {
+		ranks[i] = make([]int, len(scores[i]))
+	}
+
+	for day := 1; day <= b.Days(); day++ {
+		for i := range scores {
+			rank := 1
+			for j := range scores {
+				if scores[j][day] > scores[i][day] {
+					rank++
+				}
+			}
+			ranks[i][day] = rank
+		}
+	}
+
+	return ranks
+}
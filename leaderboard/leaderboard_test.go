@@ -0,0 +1,94 @@
+package leaderboard
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func loadBoard(t *testing.T) Board {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/board.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	board, err := Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return board
+}
+
+func TestParse(t *testing.T) {
+	board := loadBoard(t)
+
+	if board.Event != 2024 || board.Days() != 2 {
+		t.Errorf("got event %d with %d days", board.Event, board.Days())
+	}
+
+	// Alice and Bob tie on local score, so are in ID order.
+	names := make([]string, len(board.Members))
+	for i, member := range board.Members {
+		names[i] = member.Name
+	}
+	want := []string{"Bob", "Alice", "(anonymous user #3003)", "Dave"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("got members %v, want %v", names, want)
+	}
+
+	if solve, ok := board.SolveTime(board.Members[0], 1, 2); !ok || solve != 300*time.Second {
+		t.Errorf("got Bob's day 1 part 2 in %v, %t", solve, ok)
+	}
+	if _, ok := board.SolveTime(board.Members[0], 2, 2); ok {
+		t.Error("Bob has a star for day 2 part 2")
+	}
+}
+
+func TestScoresAndRanks(t *testing.T) {
+	board := loadBoard(t)
+
+	// Day 1 part 1: Alice and Bob star in the same second, and Alice's
+	// star index is lower, so she takes the 4 points.
+	wantScores := [][]int{
+		{0, 6, 10},
+		{0, 8, 10},
+		{0, 2, 5},
+		{0, 0, 0},
+	}
+	if scores := board.Scores(); !reflect.DeepEqual(scores, wantScores) {
+		t.Errorf("got scores %v, want %v", scores, wantScores)
+	}
+
+	// The scores agree with the board's own totals.
+	for i, member := range board.Members {
+		if got := wantScores[i][2]; got != member.LocalScore {
+			t.Errorf("%s: worked out %d points, board has %d", member.Name, got, member.LocalScore)
+		}
+	}
+
+	// Alice and Bob share first place after day 2.
+	wantRanks := [][]int{
+		{0, 2, 1},
+		{0, 1, 1},
+		{0, 3, 3},
+		{0, 4, 4},
+	}
+	if ranks := board.Ranks(); !reflect.DeepEqual(ranks, wantRanks) {
+		t.Errorf("got ranks %v, want %v", ranks, wantRanks)
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	unlock := Unlock(2024, 1)
+	if !unlock.Equal(time.Date(2024, time.December, 1, 5, 0, 0, 0, time.UTC)) || unlock.Unix() != 1733029200 {
+		t.Errorf("got %v", unlock)
+	}
+
+	if eastern, err := time.LoadLocation("America/New_York"); err == nil {
+		local := Unlock(2024, 25).In(eastern)
+		if local.Day() != 25 || local.Hour() != 0 || local.Minute() != 0 {
+			t.Errorf("day 25 unlocks at %v in New York", local)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package leaderboard
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"tea-cats.co.uk/aoc/ledger"
+	"tea-cats.co.uk/aoc/site"
+	"text/tabwriter"
+	"time"
+)
+
+// FormatDuration shows a solve time as h:mm:ss, with days if needed.
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+
+	clock := fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+	if days > 0 {
+		return fmt.Sprintf("%dd %s", days, clock)
+	}
+	return clock
+}
+
+// WriteStarTimes lists, for each day, how long each member took to get each
+// star and the gap between the two parts.
+func WriteStarTimes(w io.Writer, board Board) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	for day := 1; day <= board.Days(); day++ {
+		fmt.Fprintf(table, "Day %d\tPart 1\tPart 2\tDelta\t\n", day)
+
+		for _, member := range board.Members {
+			part1, ok1 := board.SolveTime(member, day, 1)
+			part2, ok2 := board.SolveTime(member, day, 2)
+
+			if !ok1 {
+				continue
+			}
+
+			row := []string{member.Name, FormatDuration(part1), "-", "-"}
+			if ok2 {
+				row[2] = FormatDuration(part2)
+				row[3] = FormatDuration(part2 - part1)
+			}
+			fmt.Fprintf(table, "%s\t\n", strings.Join(row, "\t"))
+		}
+
+		fmt.Fprintf(table, "\t\t\t\t\n")
+	}
+
+	return table.Flush()
+}
+
+// WriteRanks shows each member's position on the board after each day.
+func WriteRanks(w io.Writer, board Board) error {
+	table := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.AlignRight)
+	ranks := board.Ranks()
+	scores := board.Scores()
+	days := board.Days()
+
+	fmt.Fprintf(table, "Member\t")
+	for day := 1; day <= days; day++ {
+		fmt.Fprintf(table, "%d\t", day)
+	}
+	fmt.Fprintf(table, "Score\t\n")
+
+	for i, member := range board.Members {
+		fmt.Fprintf(table, "%s\t", member.Name)
+		for day := 1; day <= days; day++ {
+			fmt.Fprintf(table, "%d\t", ranks[i][day])
+		}
+		fmt.Fprintf(table, "%d\t\n", scores[i][days])
+	}
+
+	return table.Flush()
+}
+
+const chartSymbols = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// WriteChart draws each member's rank over time, with the leader at the top.
+// Members sharing a rank on a day are drawn as '*'.
+func WriteChart(w io.Writer, board Board) error {
+	ranks := board.Ranks()
+	days := board.Days()
+	members := min(len(board.Members), len(chartSymbols))
+
+	for rank := 1; rank <= members; rank++ {
+		line := []byte(fmt.Sprintf("%3d |", rank))
+
+		for day := 1; day <= days; day++ {
+			cell := byte(' ')
+			for i := 0; i < members; i++ {
+				if ranks[i][day] != rank {
+					continue
+				}
+				if cell == ' ' {
+					cell = chartSymbols[i]
+				} else {
+					cell = '*'
+				}
+			}
+			line = append(line, ' ', cell, ' ')
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\n", line); err != nil {
+			return err
+		}
+	}
+
+	axis := "    +" + strings.Repeat("---", days) + "\n     "
+	for day := 1; day <= days; day++ {
+		axis += fmt.Sprintf("%3d", day)
+	}
+	if _, err := fmt.Fprintf(w, "%s\n\n", axis); err != nil {
+		return err
+	}
+
+	for i := 0; i < members; i++ {
+		if _, err := fmt.Fprintf(w, "  %c  %s\n", chartSymbols[i], board.Members[i].Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteComparison puts our own solve times, from when the ledger recorded a
+// correct answer, against the board: where we would have placed, the fastest
+// time, and optionally the time the board has for a named member. If that
+// member is us, they are left out of the positions.
+func WriteComparison(w io.Writer, board Board, history *ledger.Ledger, memberName string) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintf(table, "Day\tPart\tOurs\tPosition\tFastest\t")
+	if memberName != "" {
+		fmt.Fprintf(table, "%s\t", memberName)
+	}
+	fmt.Fprintf(table, "\n")
+
+	var me *Member
+	for i := range board.Members {
+		if board.Members[i].Name == memberName {
+			me = &board.Members[i]
+		}
+	}
+
+	for _, entry := range history.Entries {
+		if entry.Year != board.Event || entry.Verdict != site.Correct {
+			continue
+		}
+
+		ours := entry.Time.Sub(Unlock(entry.Year, entry.Day))
+		position := 1
+		fastest := time.Duration(-1)
+
+		for i, member := range board.Members {
+			// Don't race against our own entry on the board.
+			if &board.Members[i] == me {
+				continue
+			}
+
+			theirs, ok := board.SolveTime(member, entry.Day, entry.Part)
+			if !ok {
+				continue
+			}
+			if theirs < ours {
+				position++
+			}
+			if fastest < 0 || theirs < fastest {
+				fastest = theirs
+			}
+		}
+
+		fastestText := "-"
+		if fastest >= 0 {
+			fastestText = FormatDuration(fastest)
+		}
+
+		entrants := len(board.Members)
+		if me == nil {
+			entrants++
+		}
+
+		fmt.Fprintf(table, "%d\t%d\t%s\t%d/%d\t%s\t", entry.Day, entry.Part, FormatDuration(ours), position, entrants, fastestText)
+		if memberName != "" {
+			recorded := "-"
+			if me != nil {
+				if theirs, ok := board.SolveTime(*me, entry.Day, entry.Part); ok {
+					recorded = FormatDuration(theirs)
+				}
+			}
+			fmt.Fprintf(table, "%s\t", recorded)
+		}
+		fmt.Fprintf(table, "\n")
+	}
+
+	return table.Flush()
+}
@@ -0,0 +1,42 @@
+package leaderboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0:00:00"},
+		{100 * time.Second, "0:01:40"},
+		{1500 * time.Millisecond, "0:00:02"},
+		{25*time.Hour + 61*time.Second, "1d 1:01:01"},
+	}
+
+	for _, test := range tests {
+		if got := FormatDuration(test.d); got != test.want {
+			t.Errorf("FormatDuration(%v) = %s, want %s", test.d, got, test.want)
+		}
+	}
+}
+
+func TestWriteRanks(t *testing.T) {
+	var out strings.Builder
+	if err := WriteRanks(&out, loadBoard(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `                 Member 1 2 Score
+                    Bob 2 1    10
+                  Alice 1 1    10
+ (anonymous user #3003) 3 3     5
+                   Dave 4 4     0
+`
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
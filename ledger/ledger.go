@@ -0,0 +1,178 @@
+// Package ledger keeps a local history of every answer submitted to the
+// site, and what the site said about it.
+package ledger
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/site"
+	"time"
+)
+
+// PathEnv overrides DefaultPath.
+const PathEnv = "AOC_LEDGER"
+
+const DefaultPath = "answers.jsonl"
+
+var (
+	ErrSolved    = errors.New("puzzle already solved")
+	ErrDuplicate = errors.New("answer already submitted")
+	ErrKnownBad  = errors.New("answer is outside the known bounds")
+)
+
+type Entry struct {
+	Year    int           `json:"year"`
+	Day     int           `json:"day"`
+	Part    int           `json:"part"`
+	Answer  string        `json:"answer"`
+	Verdict site.Verdict  `json:"verdict"`
+	Time    time.Time     `json:"time"`
+	Wait    time.Duration `json:"wait,omitempty"`
+}
+
+func (e Entry) Puzzle() runner.Puzzle {
+	return runner.Puzzle{Year: e.Year, Day: e.Day, Part: e.Part}
+}
+
+// Ledger is an append-only list of entries, stored as one JSON object per line.
+type Ledger struct {
+	path    string
+	Entries []Entry
+}
+
+// Path is the ledger file to use, taking PathEnv into account.
+func Path() string {
+	if path := os.Getenv(PathEnv); path != "" {
+		return path
+	}
+	return DefaultPath
+}
+
+// Open reads the ledger at path. A missing file is an empty ledger.
+func Open(path string) (*Ledger, error) {
+	ledger := &Ledger{path: path}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		ledger.Entries = append(ledger.Entries, entry)
+	}
+
+	return ledger, scanner.Err()
+}
+
+// Record adds an entry to the ledger and the file behind it.
+func (l *Ledger) Record(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	l.Entries = append(l.Entries, entry)
+	return nil
+}
+
+// For returns the entries for a single puzzle, oldest first.
+func (l *Ledger) For(puzzle runner.Puzzle) []Entry {
+	entries := make([]Entry, 0)
+	for _, entry := range l.Entries {
+		if entry.Puzzle() == puzzle {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Answer is the answer the site accepted for the puzzle, if there is one.
+func (l *Ledger) Answer(puzzle runner.Puzzle) (Entry, bool) {
+	for _, entry := range l.For(puzzle) {
+		if entry.Verdict == site.Correct {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Check returns an error if submitting answer to the puzzle would be pointless:
+// it is already solved, the answer has already been judged, or it falls
+// outside the bounds set by earlier too-high and too-low verdicts.
+func (l *Ledger) Check(puzzle runner.Puzzle, answer string) error {
+	if correct, ok := l.Answer(puzzle); ok {
+		return fmt.Errorf("%w: %s is %s", ErrSolved, puzzle, correct.Answer)
+	}
+
+	value, err := strconv.ParseInt(answer, 10, 64)
+	numeric := err == nil
+
+	for _, entry := range l.For(puzzle) {
+		if !entry.Verdict.Final() {
+			continue
+		}
+		if entry.Answer == answer {
+			return fmt.Errorf("%w: %s was %s", ErrDuplicate, answer, entry.Verdict)
+		}
+		if !numeric {
+			continue
+		}
+
+		previous, err := strconv.ParseInt(entry.Answer, 10, 64)
+		if err != nil {
+			continue
+		}
+		if entry.Verdict == site.TooHigh && value >= previous {
+			return fmt.Errorf("%w: %s is too high, as %s was", ErrKnownBad, answer, entry.Answer)
+		}
+		if entry.Verdict == site.TooLow && value <= previous {
+			return fmt.Errorf("%w: %s is too low, as %s was", ErrKnownBad, answer, entry.Answer)
+		}
+	}
+
+	return nil
+}
+
+// NextSubmission is the earliest time the site will accept another answer,
+// based on the waits it has asked for.
+func (l *Ledger) NextSubmission() time.Time {
+	next := time.Time{}
+	for _, entry := range l.Entries {
+		if until := entry.Time.Add(entry.Wait); until.After(next) {
+			next = until
+		}
+	}
+	return next
+}
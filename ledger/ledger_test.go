@@ -0,0 +1,119 @@
+package ledger
+
+import (
+	"errors"
+	"path/filepath"
+	"tea-cats.co.uk/aoc/runner"
+	"tea-cats.co.uk/aoc/site"
+	"testing"
+	"time"
+)
+
+var puzzle = runner.Puzzle{Year: 2024, Day: 7, Part: 1}
+
+func entry(answer string, verdict site.Verdict) Entry {
+	return Entry{Year: puzzle.Year, Day: puzzle.Day, Part: puzzle.Part, Answer: answer, Verdict: verdict}
+}
+
+func TestCheck(t *testing.T) {
+	ledger := &Ledger{Entries: []Entry{
+		entry("500", site.TooHigh),
+		entry("100", site.TooLow),
+		entry("300", site.Incorrect),
+		entry("200", site.RateLimited),
+		entry("abc", site.Incorrect),
+		// Another part, which should not count.
+		{Year: 2024, Day: 7, Part: 2, Answer: "250", Verdict: site.Correct},
+	}}
+
+	tests := []struct {
+		answer string
+		want   error
+	}{
+		{"300", ErrDuplicate},
+		{"abc", ErrDuplicate},
+		{"500", ErrDuplicate},
+		{"600", ErrKnownBad},
+		{"100", ErrDuplicate},
+		{"50", ErrKnownBad},
+		{"-7", ErrKnownBad},
+		{"101", nil},
+		{"499", nil},
+		{"250", nil},
+		// Rate limited answers were never judged, so can be tried again.
+		{"200", nil},
+		{"def", nil},
+	}
+
+	for _, test := range tests {
+		if err := ledger.Check(puzzle, test.answer); !errors.Is(err, test.want) {
+			t.Errorf("Check(%s) = %v, want %v", test.answer, err, test.want)
+		}
+	}
+}
+
+func TestCheckSolved(t *testing.T) {
+	ledger := &Ledger{Entries: []Entry{
+		entry("100", site.TooLow),
+		entry("250", site.Correct),
+	}}
+
+	for _, answer := range []string{"250", "300"} {
+		if err := ledger.Check(puzzle, answer); !errors.Is(err, ErrSolved) {
+			t.Errorf("Check(%s) = %v, want ErrSolved", answer, err)
+		}
+	}
+}
+
+func TestNextSubmission(t *testing.T) {
+	start := time.Date(2024, time.December, 7, 5, 0, 0, 0, time.UTC)
+
+	ledger := &Ledger{}
+	if next := ledger.NextSubmission(); !next.IsZero() {
+		t.Errorf("got %v for an empty ledger", next)
+	}
+
+	ledger.Entries = []Entry{
+		{Time: start, Wait: 5 * time.Minute},
+		{Time: start.Add(time.Minute), Wait: time.Minute},
+		{Time: start.Add(2 * time.Minute)},
+	}
+	if next := ledger.NextSubmission(); !next.Equal(start.Add(5 * time.Minute)) {
+		t.Errorf("got %v, want %v", next, start.Add(5*time.Minute))
+	}
+}
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.jsonl")
+
+	ledger, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ledger.Entries) != 0 {
+		t.Fatalf("a missing file gave %v", ledger.Entries)
+	}
+
+	first := entry("100", site.TooLow)
+	first.Time = time.Date(2024, time.December, 7, 5, 1, 0, 0, time.UTC)
+	first.Wait = time.Minute
+	second := entry("150", site.Correct)
+	second.Time = first.Time.Add(2 * time.Minute)
+
+	for _, e := range []Entry{first, second} {
+		if err := ledger.Record(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reopened.Entries) != 2 || reopened.Entries[0] != first || reopened.Entries[1] != second {
+		t.Errorf("got %v, want %v", reopened.Entries, []Entry{first, second})
+	}
+	if answer, ok := reopened.Answer(puzzle); !ok || answer.Answer != "150" {
+		t.Errorf("got answer %v, %t", answer, ok)
+	}
+}
@@ -0,0 +1,123 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"tea-cats.co.uk/aoc/inputs"
+	"time"
+)
+
+var ErrTimeout = errors.New("timed out")
+
+// PanicError is what RunAll reports for a solver which panicked, so that
+// one broken puzzle does not take the others down with it.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// RunAll runs the main solver for each puzzle, with up to parallel of them
+// at once. Each input is read once, before any solver starts. Solvers which
+// take longer than the timeout (if it is not zero) are cancelled and
+// reported as failing with ErrTimeout, and ones which panic as failing with
+// a PanicError.
+//
+// The results are in the same order as the puzzles.
+func RunAll(ctx context.Context, puzzles []Puzzle, parallel int, timeout time.Duration) []Result {
+	type loaded struct {
+		data []byte
+		err  error
+	}
+
+	results := make([]Result, len(puzzles))
+	input := make(map[[2]int]loaded)
+
+	for _, puzzle := range puzzles {
+		key := [2]int{puzzle.Year, puzzle.Day}
+		if _, ok := input[key]; !ok {
+			data, err := inputs.Read(puzzle.Year, puzzle.Day)
+			input[key] = loaded{data: data, err: err}
+		}
+	}
+
+	slots := make(chan struct{}, max(parallel, 1))
+	var wait sync.WaitGroup
+
+	for i, puzzle := range puzzles {
+		results[i] = Result{Puzzle: puzzle, Variant: DefaultVariant}
+
+		data := input[[2]int{puzzle.Year, puzzle.Day}]
+		if data.err != nil {
+			results[i].Err = data.err
+			continue
+		}
+
+		solver, ok := Lookup(puzzle)
+		if !ok {
+			results[i].Err = fmt.Errorf("no solver registered for %s", puzzle)
+			continue
+		}
+
+		wait.Add(1)
+		slots <- struct{}{}
+
+		go func() {
+			defer wait.Done()
+			defer func() { <-slots }()
+
+			results[i] = RunTimeout(ctx, puzzle, recovering(solver), data.data, timeout)
+			results[i].Variant = DefaultVariant
+		}()
+	}
+
+	wait.Wait()
+	return results
+}
+
+// abandonAfter is how long a solver gets to notice that it has run out of
+// time before it is left running in the background.
+const abandonAfter = time.Second
+
+// RunTimeout runs a solver with a time limit, if the timeout is not zero.
+func RunTimeout(ctx context.Context, puzzle Puzzle, solver SolveFunc, input []byte, timeout time.Duration) Result {
+	if timeout <= 0 {
+		return measure(ctx, puzzle, solver, input)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan Result, 1)
+	go func() { done <- measure(ctx, puzzle, solver, input) }()
+
+	select {
+	case result := <-done:
+		if result.Err == nil && ctx.Err() != nil {
+			result.Err = fmt.Errorf("%w after %s, but finished in %s", ErrTimeout, timeout, result.Elapsed)
+		}
+		return result
+	case <-time.After(timeout + abandonAfter):
+		return Result{Puzzle: puzzle, Elapsed: timeout, Err: fmt.Errorf("%w after %s, and ignored cancellation", ErrTimeout, timeout)}
+	}
+}
+
+// recovering turns a panic in the solver into its error. The recover has to
+// be in the solver itself, as RunTimeout calls it from a goroutine of its own.
+func recovering(solver SolveFunc) SolveFunc {
+	return func(ctx context.Context, input []byte) (answer any, err error) {
+		defer func() {
+			if value := recover(); value != nil {
+				answer, err = nil, &PanicError{Value: value, Stack: debug.Stack()}
+			}
+		}()
+
+		return solver(ctx, input)
+	}
+}
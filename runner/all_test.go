@@ -0,0 +1,133 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"tea-cats.co.uk/aoc/inputs"
+	"testing"
+	"time"
+)
+
+// stubYear is where the stub solvers are registered, well away from any
+// real puzzles.
+const stubYear = 1
+
+// running and peak count the day 3 stubs running at once.
+var running, peak atomic.Int32
+
+func init() {
+	Register(stubYear, 1, 1, func(ctx context.Context, input []byte) (any, error) {
+		return strings.TrimSpace(string(input)), nil
+	})
+	Register(stubYear, 1, 2, func(ctx context.Context, input []byte) (any, error) {
+		panic("boom")
+	})
+
+	// Day 2 has no input.
+	Register(stubYear, 2, 1, func(ctx context.Context, input []byte) (any, error) {
+		return 2, nil
+	})
+
+	for part := 1; part <= 6; part++ {
+		Register(stubYear, 3, part, func(ctx context.Context, input []byte) (any, error) {
+			now := running.Add(1)
+			defer running.Add(-1)
+			for old := peak.Load(); now > old && !peak.CompareAndSwap(old, now); old = peak.Load() {
+			}
+
+			time.Sleep(20 * time.Millisecond)
+			return part, nil
+		})
+	}
+
+	Register(stubYear, 4, 1, func(ctx context.Context, input []byte) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+}
+
+// stubInputs gives days 1, 3 and 4 of the stub year an input.
+func stubInputs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, day := range []string{"1", "3", "4"} {
+		if err := os.WriteFile(filepath.Join(dir, "1", "input-"+day+".txt"), []byte("42\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	old := inputs.Dir
+	inputs.Dir = dir
+	t.Cleanup(func() { inputs.Dir = old })
+}
+
+func TestRunAll(t *testing.T) {
+	stubInputs(t)
+
+	puzzles := []Puzzle{
+		{stubYear, 4, 1},
+		{stubYear, 1, 1},
+		{stubYear, 1, 2},
+		{stubYear, 2, 1},
+		{stubYear, 1, 3},
+	}
+	results := RunAll(context.Background(), puzzles, 2, 50*time.Millisecond)
+
+	if len(results) != len(puzzles) {
+		t.Fatalf("got %d results for %d puzzles", len(results), len(puzzles))
+	}
+	for i, result := range results {
+		if result.Puzzle != puzzles[i] || result.Variant != DefaultVariant {
+			t.Errorf("result %d is for %s %s, want %s", i, result.Puzzle, result.Variant, puzzles[i])
+		}
+	}
+
+	if !errors.Is(results[0].Err, ErrTimeout) {
+		t.Errorf("stuck solver: got error %v, want a timeout", results[0].Err)
+	}
+	if results[1].Err != nil || results[1].AnswerString() != "42" {
+		t.Errorf("working solver: got %v, %v", results[1].Answer, results[1].Err)
+	}
+
+	var panicked *PanicError
+	if !errors.As(results[2].Err, &panicked) || panicked.Value != "boom" || len(panicked.Stack) == 0 {
+		t.Errorf("panicking solver: got error %v", results[2].Err)
+	}
+
+	if !errors.Is(results[3].Err, fs.ErrNotExist) {
+		t.Errorf("missing input: got error %v", results[3].Err)
+	}
+	if results[4].Err == nil || !strings.Contains(results[4].Err.Error(), "no solver registered") {
+		t.Errorf("missing solver: got error %v", results[4].Err)
+	}
+}
+
+func TestRunAllParallel(t *testing.T) {
+	stubInputs(t)
+
+	var puzzles []Puzzle
+	for part := 1; part <= 6; part++ {
+		puzzles = append(puzzles, Puzzle{stubYear, 3, part})
+	}
+
+	for _, parallel := range []int{1, 2, 4} {
+		peak.Store(0)
+		results := RunAll(context.Background(), puzzles, parallel, 0)
+
+		if got := peak.Load(); got != int32(parallel) {
+			t.Errorf("%d at once: peaked at %d", parallel, got)
+		}
+		for i, result := range results {
+			if result.Err != nil || result.Answer != i+1 {
+				t.Errorf("%d at once: part %d got %v, %v", parallel, i+1, result.Answer, result.Err)
+			}
+		}
+	}
+}
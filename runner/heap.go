@@ -0,0 +1,54 @@
+package runner
+
+import (
+	"runtime/metrics"
+	"time"
+)
+
+const heapMetric = "/memory/classes/heap/objects:bytes"
+
+// heapSampler polls the size of the live heap in the background, as the Go
+// runtime does not track its high-water mark.
+type heapSampler struct {
+	baseline uint64
+	done     chan struct{}
+	peak     chan uint64
+}
+
+func heapSize() uint64 {
+	sample := []metrics.Sample{{Name: heapMetric}}
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
+
+func newHeapSampler() *heapSampler {
+	sampler := &heapSampler{
+		baseline: heapSize(),
+		done:     make(chan struct{}),
+		peak:     make(chan uint64),
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+
+		peak := sampler.baseline
+		for {
+			select {
+			case <-ticker.C:
+				peak = max(peak, heapSize())
+			case <-sampler.done:
+				sampler.peak <- max(peak, heapSize())
+				return
+			}
+		}
+	}()
+
+	return sampler
+}
+
+// stop returns how far the heap grew above where it was at the start.
+func (s *heapSampler) stop() uint64 {
+	close(s.done)
+	return <-s.peak - s.baseline
+}
@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type progressKey struct{}
+
+// progressTracker keeps the last progress reported by a solver, passing
+// each report on to any listener set with WithProgress.
+type progressTracker struct {
+	parent   context.Context
+	puzzle   Puzzle
+	listener func(Puzzle, string)
+
+	mutex   sync.Mutex
+	message string
+}
+
+type listenerKey struct{}
+
+// WithProgress returns a context which passes every progress report from
+// solvers run with it to listener, so that it can be shown while they run.
+// The listener may be called from several goroutines at once.
+func WithProgress(ctx context.Context, listener func(puzzle Puzzle, message string)) context.Context {
+	return context.WithValue(ctx, listenerKey{}, listener)
+}
+
+func newProgressTracker(ctx context.Context, puzzle Puzzle) *progressTracker {
+	listener, _ := ctx.Value(listenerKey{}).(func(Puzzle, string))
+	return &progressTracker{parent: ctx, puzzle: puzzle, listener: listener}
+}
+
+func (t *progressTracker) context() context.Context {
+	return context.WithValue(t.parent, progressKey{}, t)
+}
+
+func (t *progressTracker) last() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.message
+}
+
+// Report tells the runner how far a solver has got, to show while it runs
+// and if it runs out of time. It is cheap, but long loops should still only
+// report every thousand or so iterations.
+func Report(ctx context.Context, format string, args ...any) {
+	t, ok := ctx.Value(progressKey{}).(*progressTracker)
+	if !ok {
+		return
+	}
+
+	message := fmt.Sprintf(format, args...)
+
+	t.mutex.Lock()
+	t.message = message
+	t.mutex.Unlock()
+
+	if t.listener != nil {
+		t.listener(t.puzzle, message)
+	}
+}
+
+// timeoutError marks errors caused by the context's deadline as timeouts.
+func timeoutError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
@@ -0,0 +1,197 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime"
+	"slices"
+	utils "tea-cats.co.uk/aoc/2024"
+	"tea-cats.co.uk/aoc/inputs"
+	"time"
+)
+
+// SolveFunc computes the answer to one part of a puzzle from its raw input.
+// Solvers which can take a long time should give up when the context is
+// cancelled, and tell the runner how they are getting on with Report.
+type SolveFunc func(ctx context.Context, input []byte) (any, error)
+
+// Solver is implemented by each day's package to solve both parts.
+type Solver interface {
+	Part1(ctx context.Context, input []byte) (any, error)
+	Part2(ctx context.Context, input []byte) (any, error)
+}
+
+type Puzzle struct {
+	Year int
+	Day  int
+	Part int
+}
+
+func (p Puzzle) String() string {
+	return fmt.Sprintf("%d day %d part %d", p.Year, p.Day, p.Part)
+}
+
+var solvers = make(map[Puzzle]SolveFunc)
+
+// RegisterSolver makes both parts of a day available to the runner. It is
+// intended to be called from the init function of the day's package.
+func RegisterSolver(year, day int, solver Solver) {
+	Register(year, day, 1, solver.Part1)
+	Register(year, day, 2, solver.Part2)
+}
+
+// RegisterParsed is RegisterSolver for a day whose parts work on the input
+// after it has been parsed by load, as the days scaffolded by aoc new do.
+func RegisterParsed[T any](year, day int, load func([]byte) (T, error), part1, part2 func(context.Context, T) (any, error)) {
+	Register(year, day, 1, Parsed(load, part1))
+	Register(year, day, 2, Parsed(load, part2))
+}
+
+// Parsed adapts a part which works on the parsed input to a SolveFunc, which
+// parses the raw input with load before handing it on.
+func Parsed[T any](load func([]byte) (T, error), part func(context.Context, T) (any, error)) SolveFunc {
+	return func(ctx context.Context, raw []byte) (any, error) {
+		input, err := load(raw)
+		if err != nil {
+			return nil, err
+		}
+		return part(ctx, input)
+	}
+}
+
+// Register makes a solver for a single part available to the runner.
+func Register(year, day, part int, solver SolveFunc) {
+	puzzle := Puzzle{Year: year, Day: day, Part: part}
+
+	if _, exists := solvers[puzzle]; exists {
+		panic("solver already registered for " + puzzle.String())
+	}
+
+	solvers[puzzle] = solver
+}
+
+func Lookup(puzzle Puzzle) (SolveFunc, bool) {
+	solver, ok := solvers[puzzle]
+	return solver, ok
+}
+
+// Puzzles lists every registered puzzle, in order.
+func Puzzles() []Puzzle {
+	puzzles := make([]Puzzle, 0, len(solvers))
+	for puzzle := range solvers {
+		puzzles = append(puzzles, puzzle)
+	}
+
+	slices.SortFunc(puzzles, func(a, b Puzzle) int {
+		if a.Year != b.Year {
+			return a.Year - b.Year
+		}
+		if a.Day != b.Day {
+			return a.Day - b.Day
+		}
+		return a.Part - b.Part
+	})
+
+	return puzzles
+}
+
+// Result is the outcome of running a single solver.
+type Result struct {
+	Puzzle
+	Variant string
+	Answer  any
+	Elapsed time.Duration
+	Err     error
+
+	// Progress is the last progress the solver reported.
+	Progress string
+
+	// Allocs and Bytes are the heap allocations made while solving, and
+	// PeakHeap the most the live heap grew by at any point. They are for the
+	// whole process, so are only accurate if one solver runs at a time.
+	Allocs   uint64
+	Bytes    uint64
+	PeakHeap uint64
+}
+
+// AnswerString is the answer as it would be typed into the site.
+func (r Result) AnswerString() string {
+	if r.Answer == nil {
+		return ""
+	}
+	return fmt.Sprint(r.Answer)
+}
+
+// Run loads the input for the puzzle and runs its solver against it.
+func Run(ctx context.Context, puzzle Puzzle) Result {
+	return RunVariant(ctx, puzzle, DefaultVariant)
+}
+
+// RunVariant is Run for one of the puzzle's variants.
+func RunVariant(ctx context.Context, puzzle Puzzle, variant string) Result {
+	solver, ok := LookupVariant(puzzle, variant)
+	if !ok && variant == DefaultVariant {
+		return Result{Puzzle: puzzle, Err: fmt.Errorf("no solver registered for %s", puzzle)}
+	}
+	if !ok {
+		return Result{Puzzle: puzzle, Variant: variant, Err: fmt.Errorf("no variant %q registered for %s", variant, puzzle)}
+	}
+
+	input, err := inputs.Read(puzzle.Year, puzzle.Day)
+	if err != nil {
+		return Result{Puzzle: puzzle, Variant: variant, Err: err}
+	}
+
+	result := measure(ctx, puzzle, solver, input)
+	result.Variant = variant
+	return result
+}
+
+// measure runs a solver, recording the time and memory it takes and the
+// last progress it reported.
+func measure(ctx context.Context, puzzle Puzzle, solver SolveFunc, input []byte) Result {
+	result := Result{Puzzle: puzzle}
+	progress := newProgressTracker(ctx, puzzle)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	sampler := newHeapSampler()
+
+	start := time.Now()
+	result.Answer, result.Err = solver(progress.context(), input)
+	result.Elapsed = time.Since(start)
+
+	result.PeakHeap = sampler.stop()
+
+	runtime.ReadMemStats(&after)
+	result.Allocs = after.Mallocs - before.Mallocs
+	result.Bytes = after.TotalAlloc - before.TotalAlloc
+
+	result.Progress = progress.last()
+	if result.Err != nil && ctx.Err() != nil && errors.Is(result.Err, ctx.Err()) {
+		result.Err = timeoutError(ctx, result.Err)
+	}
+
+	return result
+}
+
+// Main runs a single registered solver and prints the answer. It is used by
+// the main package for each part, so that `go run ./2024/dayN/partM` works.
+func Main(year, day, part int) {
+	MainVariant(year, day, part, DefaultVariant)
+}
+
+// MainVariant is Main for one of the puzzle's variants.
+func MainVariant(year, day, part int, variant string) {
+	defer utils.TimeTrack(time.Now(), "main")
+
+	result := RunVariant(context.Background(), Puzzle{Year: year, Day: day, Part: part}, variant)
+	if result.Err != nil {
+		log.Fatal(result.Err)
+	}
+
+	fmt.Printf("Result: %s\n", result.AnswerString())
+}
@@ -0,0 +1,27 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestParsed(t *testing.T) {
+	double := Parsed(func(raw []byte) (int, error) {
+		return strconv.Atoi(string(raw))
+	}, func(ctx context.Context, n int) (any, error) {
+		return n * 2, nil
+	})
+
+	answer, err := double(context.Background(), []byte("21"))
+	if err != nil || answer != 42 {
+		t.Errorf("got %v, %v, want 42", answer, err)
+	}
+
+	// The part isn't run when the input can't be parsed.
+	answer, err = double(context.Background(), []byte("twenty-one"))
+	if !errors.Is(err, strconv.ErrSyntax) || answer != nil {
+		t.Errorf("got %v, %v, want a syntax error", answer, err)
+	}
+}
@@ -0,0 +1,42 @@
+// Package runnertest checks solvers against the examples from the puzzle
+// descriptions, which each day keeps in its testdata.
+package runnertest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"tea-cats.co.uk/aoc/runner"
+	"testing"
+)
+
+// Example is one worked example: the answer Solve should give for the input
+// in File. Name is the subtest it runs as, and what aoc watch reports.
+type Example struct {
+	Name  string
+	File  string
+	Solve runner.SolveFunc
+	Want  string
+}
+
+// Examples runs each example as a subtest, comparing the answer as printed.
+func Examples(t *testing.T, examples []Example) {
+	t.Helper()
+
+	for _, example := range examples {
+		t.Run(example.Name, func(t *testing.T) {
+			raw, err := os.ReadFile(example.File)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := example.Solve(context.Background(), raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(got) != example.Want {
+				t.Errorf("got %v, want %s", got, example.Want)
+			}
+		})
+	}
+}
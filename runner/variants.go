@@ -0,0 +1,118 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// DefaultVariant is the name given to the solver passed to Register when it is
+// listed alongside the other variants of a puzzle.
+const DefaultVariant = "main"
+
+// ErrMismatch is returned by Compare when variants give different answers.
+var ErrMismatch = errors.New("variants disagree")
+
+// Variant is an alternative implementation of a puzzle's solver, such as the
+// original version an optimised solver was derived from.
+type Variant struct {
+	Name   string
+	Solver SolveFunc
+}
+
+var variants = make(map[Puzzle][]Variant)
+
+// RegisterVariant adds an alternative solver for a puzzle, to be checked
+// against the main one. Like Register, it is intended to be called from init.
+func RegisterVariant(year, day, part int, name string, solver SolveFunc) {
+	puzzle := Puzzle{Year: year, Day: day, Part: part}
+
+	if name == DefaultVariant || slices.ContainsFunc(variants[puzzle], func(v Variant) bool { return v.Name == name }) {
+		panic(fmt.Sprintf("variant %q already registered for %s", name, puzzle))
+	}
+
+	variants[puzzle] = append(variants[puzzle], Variant{Name: name, Solver: solver})
+}
+
+// Variants lists every solver for the puzzle, the main one first, then the
+// others in the order they were registered.
+func Variants(puzzle Puzzle) []Variant {
+	result := make([]Variant, 0, len(variants[puzzle])+1)
+
+	if solver, ok := Lookup(puzzle); ok {
+		result = append(result, Variant{Name: DefaultVariant, Solver: solver})
+	}
+
+	return append(result, variants[puzzle]...)
+}
+
+// LookupVariant finds a solver by its variant name.
+func LookupVariant(puzzle Puzzle, name string) (SolveFunc, bool) {
+	for _, v := range Variants(puzzle) {
+		if v.Name == name {
+			return v.Solver, true
+		}
+	}
+	return nil, false
+}
+
+// Compare runs every variant of the puzzle on the same input, one after the
+// other. If any of them fail or give a different answer to the first, the
+// error wraps ErrMismatch; the results are returned either way.
+func Compare(ctx context.Context, puzzle Puzzle, input []byte) ([]Result, error) {
+	all := Variants(puzzle)
+	if len(all) == 0 {
+		return nil, fmt.Errorf("no solver registered for %s", puzzle)
+	}
+
+	results := make([]Result, len(all))
+	problems := make([]string, 0)
+
+	for i, v := range all {
+		results[i] = measure(ctx, puzzle, v.Solver, input)
+		results[i].Variant = v.Name
+
+		switch {
+		case results[i].Err != nil:
+			problems = append(problems, fmt.Sprintf("%s failed: %v", v.Name, results[i].Err))
+		case i > 0 && results[0].Err == nil && results[i].AnswerString() != results[0].AnswerString():
+			problems = append(problems, fmt.Sprintf("%s answered %s, %s answered %s", v.Name, results[i].AnswerString(), all[0].Name, results[0].AnswerString()))
+		}
+	}
+
+	if len(problems) > 0 {
+		return results, fmt.Errorf("%s: %w: %s", puzzle, ErrMismatch, strings.Join(problems, "; "))
+	}
+
+	return results, nil
+}
+
+// WriteComparison prints the results from Compare as a table, with times and
+// allocations relative to the first variant.
+func WriteComparison(w io.Writer, results []Result) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(table, "variant\tanswer\ttime\t\tallocs\tbytes\t")
+
+	for _, r := range results {
+		answer := r.AnswerString()
+		if r.Err != nil {
+			answer = "error"
+		}
+
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%d\t%d\t\n",
+			r.Variant, answer, r.Elapsed, ratio(r.Elapsed.Seconds(), results[0].Elapsed.Seconds()), r.Allocs, r.Bytes)
+	}
+
+	return table.Flush()
+}
+
+func ratio(value, base float64) string {
+	if base == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("x%.2f", value/base)
+}
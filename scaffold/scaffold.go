@@ -0,0 +1,173 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var templates = template.Must(template.ParseFS(templateFiles, "templates/*.tmpl"))
+
+// RegistryFile is the file in the aoc command which imports every day that
+// registers solvers with the runner.
+var RegistryFile = filepath.Join("cmd", "aoc", "solvers.go")
+
+var ErrDayExists = errors.New("day already exists")
+
+type templateData struct {
+	Year int
+	Day  int
+	Part int
+}
+
+// New creates the package for a new day under root, with a shared loader,
+// solver stubs for both parts, and an example test, then adds the day to
+// the aoc command's list of solvers. The loader uses the helpers in the
+// year's own utils package, at the top of the year's directory, which is
+// created as well for the first day of a year.
+func New(root string, year, day int) error {
+	if year < 2015 || year > 9999 {
+		return fmt.Errorf("invalid year %d", year)
+	}
+	if day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %d", day)
+	}
+
+	dir := filepath.Join(root, fmt.Sprint(year), fmt.Sprintf("day%d", day))
+
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%w: %s", ErrDayExists, dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	yearDir := filepath.Join(root, fmt.Sprint(year))
+	hasUtils, err := hasGoFiles(yearDir)
+	if err != nil {
+		return err
+	}
+	if !hasUtils {
+		if err := writeTemplate(filepath.Join(yearDir, "utils.go"), "utils.go.tmpl", templateData{Year: year}); err != nil {
+			return err
+		}
+	}
+
+	files := []struct {
+		template string
+		path     string
+		part     int
+	}{
+		{"load.go.tmpl", "load.go", 0},
+		{"solve.go.tmpl", "solve.go", 0},
+		{"solve_test.go.tmpl", "solve_test.go", 0},
+		{"main.go.tmpl", filepath.Join("part1", "main.go"), 1},
+		{"main.go.tmpl", filepath.Join("part2", "main.go"), 2},
+	}
+
+	for _, file := range files {
+		err := writeTemplate(filepath.Join(dir, file.path), file.template, templateData{Year: year, Day: day, Part: file.part})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "testdata"), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "testdata", "example-1.txt"), nil, 0644); err != nil {
+		return err
+	}
+
+	return WriteRegistry(root)
+}
+
+// hasGoFiles reports whether there is a Go package directly in dir.
+func hasGoFiles(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(entries, func(entry fs.DirEntry) bool {
+		return !entry.IsDir() && strings.HasSuffix(entry.Name(), ".go")
+	}), nil
+}
+
+var dayPackage = regexp.MustCompile(`^[0-9]{4}/day[0-9]+$`)
+
+// WriteRegistry regenerates RegistryFile from every day package under root
+// which registers solvers with the runner.
+func WriteRegistry(root string) error {
+	matches, err := filepath.Glob(filepath.Join(root, "[0-9][0-9][0-9][0-9]", "day*", "*.go"))
+	if err != nil {
+		return err
+	}
+
+	packages := make([]string, 0)
+
+	for _, match := range matches {
+		if strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+
+		pkg, err := filepath.Rel(root, filepath.Dir(match))
+		if err != nil {
+			return err
+		}
+		pkg = filepath.ToSlash(pkg)
+
+		if !dayPackage.MatchString(pkg) || slices.Contains(packages, pkg) {
+			continue
+		}
+
+		source, err := os.ReadFile(match)
+		if err != nil {
+			return err
+		}
+		if bytes.Contains(source, []byte("runner.Register")) {
+			packages = append(packages, pkg)
+		}
+	}
+
+	slices.Sort(packages)
+
+	return writeTemplate(filepath.Join(root, RegistryFile), "solvers.go.tmpl", packages)
+}
+
+func writeTemplate(path string, name string, data any) error {
+	var buffer bytes.Buffer
+
+	if err := templates.ExecuteTemplate(&buffer, name, data); err != nil {
+		return err
+	}
+
+	source := buffer.Bytes()
+	if strings.HasSuffix(path, ".go") {
+		formatted, err := format.Source(source)
+		if err != nil {
+			return fmt.Errorf("formatting %s: %w", path, err)
+		}
+		source = formatted
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, source, 0644)
+}
@@ -0,0 +1,255 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	root := t.TempDir()
+
+	if err := New(root, 2023, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(root, "2023", "day5")
+	for _, file := range []string{"load.go", "solve.go", "solve_test.go", "part1/main.go", "part2/main.go", "testdata/example-1.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Error(err)
+		}
+	}
+
+	utils, err := os.ReadFile(filepath.Join(root, "2023", "utils.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(utils), "inputs.Open(2023, day)") {
+		t.Errorf("the 2023 utils package does not open 2023 inputs:\n%s", utils)
+	}
+
+	load, err := os.ReadFile(filepath.Join(dir, "load.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(load), `utils "tea-cats.co.uk/aoc/2023"`) {
+		t.Errorf("load.go does not use the 2023 utils package:\n%s", load)
+	}
+
+	main, err := os.ReadFile(filepath.Join(dir, "part2", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(main), "runner.Main(2023, 5, 2)") {
+		t.Errorf("part2/main.go does not run part 2:\n%s", main)
+	}
+
+	registry, err := os.ReadFile(filepath.Join(root, RegistryFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(registry), `_ "tea-cats.co.uk/aoc/2023/day5"`) {
+		t.Errorf("the new day is not registered:\n%s", registry)
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	root := t.TempDir()
+
+	for _, date := range [][2]int{{2014, 1}, {2024, 0}, {2024, 26}} {
+		if err := New(root, date[0], date[1]); err == nil {
+			t.Errorf("created %d day %d", date[0], date[1])
+		}
+	}
+}
+
+func TestNewExisting(t *testing.T) {
+	root := t.TempDir()
+
+	if err := New(root, 2024, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	load := filepath.Join(root, "2024", "day1", "load.go")
+	if err := os.WriteFile(load, []byte("package day1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := New(root, 2024, 1); !errors.Is(err, ErrDayExists) {
+		t.Errorf("got %v, want ErrDayExists", err)
+	}
+
+	source, err := os.ReadFile(load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(source) != "package day1\n" {
+		t.Errorf("load.go was overwritten:\n%s", source)
+	}
+}
+
+// TestNewKeepsUtils checks that a year's utils package is left alone when
+// there already is one.
+func TestNewKeepsUtils(t *testing.T) {
+	root := t.TempDir()
+
+	helpers := filepath.Join(root, "2024", "helpers.go")
+	if err := os.MkdirAll(filepath.Dir(helpers), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(helpers, []byte("package utils\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := New(root, 2024, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "2024", "utils.go")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("wrote a second utils package: %v", err)
+	}
+}
+
+func TestWriteRegistry(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		"2024/day1/solve.go":      "package day1\n\nfunc init() { runner.RegisterSolver(2024, 1, nil) }\n",
+		"2024/day1/load.go":       "package day1\n\nfunc init() { runner.RegisterSolver(2024, 1, nil) }\n",
+		"2024/day10/solve.go":     "package day10\n\nfunc init() { runner.RegisterSolver(2024, 10, nil) }\n",
+		"2024/day2/load.go":       "package day2\n",
+		"2024/day3/solve_test.go": "package day3\n\nfunc init() { runner.RegisterSolver(2024, 3, nil) }\n",
+		"2024/graph/graph.go":     "package graph\n\nfunc init() { runner.RegisterSolver(2024, 0, nil) }\n",
+		"2015/day1/solve.go":      "package day1\n\nfunc init() { runner.RegisterSolver(2015, 1, nil) }\n",
+	}
+	for path, source := range files {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := WriteRegistry(root); err != nil {
+		t.Fatal(err)
+	}
+
+	registry, err := os.ReadFile(filepath.Join(root, RegistryFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `// Code generated by aoc new; DO NOT EDIT.
+
+package main
+
+import (
+	_ "tea-cats.co.uk/aoc/2015/day1"
+	_ "tea-cats.co.uk/aoc/2024/day1"
+	_ "tea-cats.co.uk/aoc/2024/day10"
+)
+`
+	if string(registry) != want {
+		t.Errorf("got\n%s\nwant\n%s", registry, want)
+	}
+}
+
+// TestNewBuilds scaffolds a day missing from this repository, then builds
+// it, its test and the aoc command in place using an overlay, so that the
+// templates are checked against the real runner and utils packages. It does
+// so for a year which is already here, and for one which is not, where the
+// year's utils package has to be created too.
+func TestNewBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds with the go command")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go command")
+	}
+
+	repo, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	missing := func(path ...string) bool {
+		_, err := os.Stat(filepath.Join(append([]string{repo}, path...)...))
+		return errors.Is(err, os.ErrNotExist)
+	}
+
+	t.Run("existing year", func(t *testing.T) {
+		for day := 1; day <= 25; day++ {
+			if missing("2024", fmt.Sprintf("day%d", day)) {
+				buildNew(t, goCmd, repo, 2024, day)
+				return
+			}
+		}
+		t.Skip("every day of 2024 already exists")
+	})
+
+	t.Run("new year", func(t *testing.T) {
+		for year := 2015; year <= 9999; year++ {
+			if missing(fmt.Sprint(year)) {
+				buildNew(t, goCmd, repo, year, 1)
+				return
+			}
+		}
+	})
+}
+
+// buildNew scaffolds a day into a scratch directory, and overlays what it
+// wrote onto the repository to build it.
+func buildNew(t *testing.T, goCmd, repo string, year, day int) {
+	root := t.TempDir()
+	if err := New(root, year, day); err != nil {
+		t.Fatal(err)
+	}
+
+	overlay := map[string]string{}
+	err := filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".go") {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		overlay[filepath.Join(repo, rel)] = path
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := json.Marshal(map[string]any{"Replace": overlay})
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlayFile := filepath.Join(t.TempDir(), "overlay.json")
+	if err := os.WriteFile(overlayFile, config, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Compiling the test binary checks solve_test.go as well. The registry
+	// only lists the new day, which is enough to check that aoc still builds
+	// with it.
+	pkg := fmt.Sprintf("./%d/day%d", year, day)
+	for _, args := range [][]string{
+		{"build", "-overlay", overlayFile, pkg + "/...", "./cmd/aoc"},
+		{"test", "-overlay", overlayFile, "-vet=off", "-c", "-o", t.TempDir(), pkg},
+	} {
+		cmd := exec.Command(goCmd, args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("go %s: %v\n%s", args[0], err, out)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Package site talks to the Advent of Code website.
+//
+// Everything goes through a Client, so that the base URL can be pointed at
+// an httptest server, and so that all requests share the same throttle.
+package site
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultBaseURL = "https://adventofcode.com"
+
+// UserAgent identifies this tool to the site, as requested by its authors.
+const UserAgent = "tea-cats.co.uk/aoc (+https://github.com/javajawa/aoc)"
+
+// DefaultThrottle is the minimum gap between two requests from one client.
+const DefaultThrottle = 5 * time.Second
+
+type Client struct {
+	BaseURL  string
+	Session  string
+	Throttle time.Duration
+	HTTP     *http.Client
+
+	mutex       sync.Mutex
+	lastRequest time.Time
+}
+
+func NewClient(session string) *Client {
+	return &Client{
+		BaseURL:  DefaultBaseURL,
+		Session:  session,
+		Throttle: DefaultThrottle,
+		HTTP:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// StatusError is returned when the site responds with anything but 200 OK.
+type StatusError struct {
+	URL    string
+	Status int
+	Body   string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: HTTP %d: %s", e.URL, e.Status, strings.TrimSpace(e.Body))
+}
+
+// ErrNotReleased matches a StatusError for a day which is not out yet, which
+// the site answers with 404 Not Found.
+var ErrNotReleased = errors.New("puzzle not released yet")
+
+func (e *StatusError) Is(target error) bool {
+	return target == ErrNotReleased && e.Status == http.StatusNotFound
+}
+
+// wait blocks until the throttle allows another request to be made.
+func (c *Client) wait() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if next := c.lastRequest.Add(c.Throttle); time.Now().Before(next) {
+		time.Sleep(time.Until(next))
+	}
+	c.lastRequest = time.Now()
+}
+
+func (c *Client) do(request *http.Request) ([]byte, error) {
+	request.Header.Set("User-Agent", UserAgent)
+	if c.Session != "" {
+		request.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	}
+
+	c.wait()
+
+	response, err := c.HTTP.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, &StatusError{URL: request.URL.String(), Status: response.StatusCode, Body: string(body)}
+	}
+
+	return body, nil
+}
+
+func (c *Client) get(path string) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(request)
+}
@@ -0,0 +1,130 @@
+package site
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestClient is a client for server which does not wait between requests.
+func newTestClient(server *httptest.Server, session string) *Client {
+	client := NewClient(session)
+	client.BaseURL = server.URL
+	client.Throttle = 0
+	return client
+}
+
+func TestClientHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != UserAgent {
+			t.Errorf("got User-Agent %q, want %q", got, UserAgent)
+		}
+
+		cookie, err := r.Cookie("session")
+		if err != nil {
+			t.Errorf("no session cookie: %v", err)
+		} else if cookie.Value != "53cr3t" {
+			t.Errorf("got session %q, want 53cr3t", cookie.Value)
+		}
+
+		if r.URL.Path != "/2024/day/3/input" {
+			t.Errorf("got path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte("input\n"))
+	}))
+	defer server.Close()
+
+	body, err := newTestClient(server, "53cr3t").Input(2024, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "input\n" {
+		t.Errorf("got body %q", body)
+	}
+}
+
+func TestClientNoSessionCookie(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("session"); err == nil {
+			t.Error("sent a session cookie without a session")
+		}
+		_, _ = w.Write([]byte("page"))
+	}))
+	defer server.Close()
+
+	if _, err := newTestClient(server, "").Puzzle(2024, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientThrottle(t *testing.T) {
+	var mutex sync.Mutex
+	times := make([]time.Time, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		times = append(times, time.Now())
+		mutex.Unlock()
+		_, _ = w.Write([]byte("page"))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server, "session")
+	client.Throttle = 50 * time.Millisecond
+
+	var wait sync.WaitGroup
+	for day := 1; day <= 3; day++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			if _, err := client.Puzzle(2024, day); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wait.Wait()
+
+	if len(times) != 3 {
+		t.Fatalf("got %d requests, want 3", len(times))
+	}
+	for i := 1; i < len(times); i++ {
+		// Allow for the server seeing the requests a little unevenly.
+		if gap := times[i].Sub(times[i-1]); gap < 40*time.Millisecond {
+			t.Errorf("requests %d and %d were %v apart", i-1, i, gap)
+		}
+	}
+}
+
+func TestClientStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2024/day/25/input":
+			http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+		default:
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server, "session")
+
+	_, err := client.Input(2024, 25)
+	var status *StatusError
+	if !errors.As(err, &status) || status.Status != http.StatusNotFound {
+		t.Errorf("got %v, want a 404 StatusError", err)
+	}
+	if !errors.Is(err, ErrNotReleased) {
+		t.Errorf("%v is not ErrNotReleased", err)
+	}
+
+	_, err = client.Input(2024, 1)
+	if !errors.As(err, &status) || status.Status != http.StatusBadRequest {
+		t.Errorf("got %v, want a 400 StatusError", err)
+	}
+	if errors.Is(err, ErrNotReleased) {
+		t.Errorf("%v should not be ErrNotReleased", err)
+	}
+}
@@ -0,0 +1,55 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SessionEnv is the environment variable checked for the session cookie.
+const SessionEnv = "AOC_SESSION"
+
+var ErrNoSession = errors.New("no session token: set " + SessionEnv + " or write it to " + sessionFileDescription)
+
+const sessionFileDescription = "$XDG_CONFIG_HOME/aoc/session"
+
+// SessionFile is where the session token is read from if it is not set in the
+// environment.
+func SessionFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc", "session"), nil
+}
+
+// LoadSession finds the session cookie value, from the environment first and
+// then the config file.
+func LoadSession() (string, error) {
+	if session := strings.TrimSpace(os.Getenv(SessionEnv)); session != "" {
+		return session, nil
+	}
+
+	path, err := SessionFile()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNoSession
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading session: %w", err)
+	}
+
+	session := strings.TrimSpace(string(data))
+	if session == "" {
+		return "", ErrNoSession
+	}
+
+	return session, nil
+}
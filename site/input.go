@@ -0,0 +1,86 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"tea-cats.co.uk/aoc/inputs"
+)
+
+func (c *Client) Input(year, day int) ([]byte, error) {
+	return c.get(fmt.Sprintf("/%d/day/%d/input", year, day))
+}
+
+// Puzzle is the page describing the puzzle. Part two is only included once
+// part one has been solved by the session's user.
+func (c *Client) Puzzle(year, day int) ([]byte, error) {
+	return c.get(fmt.Sprintf("/%d/day/%d", year, day))
+}
+
+// FetchInput downloads the input for a day to path. If path already exists,
+// or an encrypted copy of it does, the site is not contacted at all; fetched
+// reports whether a download happened.
+func (c *Client) FetchInput(year, day int, path string) (fetched bool, err error) {
+	if exists(path + inputs.EncryptedSuffix) {
+		return false, nil
+	}
+	if c.Session == "" && !exists(path) {
+		return false, ErrNoSession
+	}
+	return c.fetchTo(path, func() ([]byte, error) { return c.Input(year, day) })
+}
+
+// FetchPuzzle saves the puzzle page for a day to path, with the same caching
+// as FetchInput. To pick up part two, the cached page has to be removed.
+func (c *Client) FetchPuzzle(year, day int, path string) (fetched bool, err error) {
+	return c.fetchTo(path, func() ([]byte, error) { return c.Puzzle(year, day) })
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func (c *Client) fetchTo(path string, get func() ([]byte, error)) (bool, error) {
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+
+	data, err := get()
+	if err != nil {
+		return false, err
+	}
+	if len(data) == 0 {
+		return false, fmt.Errorf("empty response for %s", path)
+	}
+
+	return true, writeFile(path, data)
+}
+
+// writeFile replaces the file at path atomically, so that an interrupted
+// download never leaves a partial file that looks cached.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(temp.Name()) }()
+
+	if _, err := temp.Write(data); err != nil {
+		_ = temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
@@ -0,0 +1,149 @@
+package site
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"tea-cats.co.uk/aoc/inputs"
+	"testing"
+)
+
+// countingServer serves body for every request, counting them.
+func countingServer(t *testing.T, body string) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestFetchInput(t *testing.T) {
+	server, requests := countingServer(t, "1 2 3\n")
+	client := newTestClient(server, "session")
+	path := filepath.Join(t.TempDir(), "2024", "input-1.txt")
+
+	fetched, err := client.FetchInput(2024, 1, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fetched {
+		t.Error("the first fetch did not download")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "1 2 3\n" {
+		t.Errorf("saved %q", data)
+	}
+
+	fetched, err = client.FetchInput(2024, 1, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched {
+		t.Error("the second fetch downloaded again")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
+
+func TestFetchInputExisting(t *testing.T) {
+	server, requests := countingServer(t, "new\n")
+	path := filepath.Join(t.TempDir(), "input-1.txt")
+	if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a session too, as nothing needs to be fetched.
+	for _, session := range []string{"session", ""} {
+		fetched, err := newTestClient(server, session).FetchInput(2024, 1, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fetched {
+			t.Errorf("session %q: fetched over an existing file", session)
+		}
+	}
+
+	if n := requests.Load(); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old\n" {
+		t.Errorf("the file was replaced with %q", data)
+	}
+}
+
+func TestFetchInputNoSession(t *testing.T) {
+	server, requests := countingServer(t, "input\n")
+	path := filepath.Join(t.TempDir(), "input-1.txt")
+
+	_, err := newTestClient(server, "").FetchInput(2024, 1, path)
+	if !errors.Is(err, ErrNoSession) {
+		t.Errorf("got %v, want ErrNoSession", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Error("a file was written")
+	}
+}
+
+func TestFetchInputFailure(t *testing.T) {
+	for name, handler := range map[string]http.HandlerFunc{
+		"error": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusInternalServerError)
+		},
+		"empty": func(w http.ResponseWriter, r *http.Request) {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "input-1.txt")
+
+			if _, err := newTestClient(server, "session").FetchInput(2024, 1, path); err == nil {
+				t.Error("no error")
+			}
+
+			// Nothing is left behind to look like a cached input.
+			if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+				t.Errorf("left %v", entries)
+			}
+		})
+	}
+}
+
+func TestFetchInputEncrypted(t *testing.T) {
+	server, requests := countingServer(t, "input\n")
+	path := filepath.Join(t.TempDir(), "input-1.txt")
+	if err := os.WriteFile(path+inputs.EncryptedSuffix, []byte("AOC-ENC2..."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, session := range []string{"session", ""} {
+		fetched, err := newTestClient(server, session).FetchInput(2024, 1, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fetched {
+			t.Errorf("session %q: fetched with an encrypted copy cached", session)
+		}
+	}
+
+	if n := requests.Load(); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Error("a plain text copy was written")
+	}
+}
@@ -0,0 +1,112 @@
+package site
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Verdict string
+
+const (
+	Correct       Verdict = "correct"
+	Incorrect     Verdict = "incorrect"
+	TooHigh       Verdict = "too-high"
+	TooLow        Verdict = "too-low"
+	RateLimited   Verdict = "rate-limited"
+	AlreadySolved Verdict = "already-solved"
+	Unknown       Verdict = "unknown"
+)
+
+// Final is whether the verdict says anything about the answer itself, as
+// opposed to the submission not being considered at all.
+func (v Verdict) Final() bool {
+	switch v {
+	case Correct, Incorrect, TooHigh, TooLow:
+		return true
+	}
+	return false
+}
+
+// Response is the site's reply to a submitted answer.
+type Response struct {
+	Verdict Verdict
+	// Wait is how long the site has asked us to leave before the next attempt.
+	Wait    time.Duration
+	Message string
+}
+
+func (c *Client) Submit(year, day, part int, answer string) (Response, error) {
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {answer},
+	}
+
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/%d/day/%d/answer", c.BaseURL, year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Response{}, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	page, err := c.do(request)
+	if err != nil {
+		return Response{}, err
+	}
+
+	return ParseResponse(page), nil
+}
+
+var (
+	articlePattern    = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern        = regexp.MustCompile(`<[^>]*>`)
+	spacePattern      = regexp.MustCompile(`\s+`)
+	leftPattern       = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	pleaseWaitPattern = regexp.MustCompile(`(?i)please wait (one|\d+) minutes?`)
+)
+
+// ParseResponse reads the verdict out of the page returned after submitting
+// an answer.
+func ParseResponse(page []byte) Response {
+	message := string(page)
+	if match := articlePattern.FindStringSubmatch(message); match != nil {
+		message = match[1]
+	}
+	message = html.UnescapeString(tagPattern.ReplaceAllString(message, ""))
+	message = strings.TrimSpace(spacePattern.ReplaceAllString(message, " "))
+
+	response := Response{Verdict: Unknown, Message: message}
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		response.Verdict = Correct
+	case strings.Contains(message, "You gave an answer too recently"):
+		response.Verdict = RateLimited
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		response.Verdict = AlreadySolved
+	case strings.Contains(message, "your answer is too high"):
+		response.Verdict = TooHigh
+	case strings.Contains(message, "your answer is too low"):
+		response.Verdict = TooLow
+	case strings.Contains(message, "That's not the right answer"):
+		response.Verdict = Incorrect
+	}
+
+	if match := leftPattern.FindStringSubmatch(message); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		response.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if match := pleaseWaitPattern.FindStringSubmatch(message); match != nil {
+		minutes := 1
+		if match[1] != "one" {
+			minutes, _ = strconv.Atoi(match[1])
+		}
+		response.Wait = time.Duration(minutes) * time.Minute
+	}
+
+	return response
+}
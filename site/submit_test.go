@@ -0,0 +1,106 @@
+package site
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		file    string
+		verdict Verdict
+		wait    time.Duration
+		message string
+	}{
+		{"right.html", Correct, 0, "That's the right answer! You are one gold star closer"},
+		{"too-high.html", TooHigh, time.Minute, "your answer is too high."},
+		{"too-low.html", TooLow, 5 * time.Minute, "your answer is too low."},
+		{"wrong.html", Incorrect, time.Minute, "That's not the right answer. If"},
+		{"wait.html", RateLimited, 34 * time.Second, "You have 34s left to wait. [Return to Day 7]"},
+		{"wait-minutes.html", RateLimited, 4*time.Minute + 12*time.Second, "You have 4m 12s left to wait."},
+		// The site gives the same page for a part which is already solved
+		// and for one which is not unlocked yet.
+		{"already-solved.html", AlreadySolved, 0, "Did you already complete it? [Return to Day 7]"},
+		{"wrong-level.html", AlreadySolved, 0, "Did you already complete it? [Return to Day 8]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			page, err := os.ReadFile("testdata/" + test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			response := ParseResponse(page)
+			if response.Verdict != test.verdict {
+				t.Errorf("got verdict %s, want %s", response.Verdict, test.verdict)
+			}
+			if response.Wait != test.wait {
+				t.Errorf("got wait %v, want %v", response.Wait, test.wait)
+			}
+			if !strings.Contains(response.Message, test.message) {
+				t.Errorf("message %q does not contain %q", response.Message, test.message)
+			}
+			if strings.ContainsAny(response.Message, "<>") || strings.Contains(response.Message, "Advent of Code") {
+				t.Errorf("message %q has more than the article in it", response.Message)
+			}
+		})
+	}
+}
+
+func TestParseResponseUnknown(t *testing.T) {
+	response := ParseResponse([]byte("<html><body><p>Something  &amp; else</p></body></html>"))
+	if response.Verdict != Unknown || response.Verdict.Final() {
+		t.Errorf("got verdict %s", response.Verdict)
+	}
+	if response.Message != "Something & else" {
+		t.Errorf("got message %q", response.Message)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	page, err := os.ReadFile("testdata/too-low.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/7/answer" {
+			t.Errorf("got %s %s", r.Method, r.URL.Path)
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "53cr3t" {
+			t.Errorf("got session cookie %v, %v", cookie, err)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		if level, answer := r.PostForm.Get("level"), r.PostForm.Get("answer"); level != "2" || answer != "1234" {
+			t.Errorf("got level %q and answer %q", level, answer)
+		}
+		_, _ = w.Write(page)
+	}))
+	defer server.Close()
+
+	response, err := newTestClient(server, "53cr3t").Submit(2024, 7, 2, "1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Verdict != TooLow || response.Wait != 5*time.Minute {
+		t.Errorf("got %s with wait %v", response.Verdict, response.Wait)
+	}
+}
+
+func TestSubmitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	if _, err := newTestClient(server, "session").Submit(2024, 7, 1, "1"); err == nil {
+		t.Error("no error")
+	}
+}